@@ -0,0 +1,134 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore records the tasks of a [Graph] that succeeded, so that a later run
+// resumes where a failed run stopped (see [WithCheckpoint]).
+//
+// Outputs are the JSON encoding of the values of [Node] tasks, and nil for other tasks.
+type CheckpointStore interface {
+	// Load returns the outputs of the tasks that succeeded in previous runs, by task name.
+	Load(ctx context.Context) (map[string]json.RawMessage, error)
+	// Save records that the task named task succeeded with output.
+	Save(ctx context.Context, task string, output json.RawMessage) error
+}
+
+// WithCheckpoint makes [Rendezvous.RunGraph] record the tasks that succeed in store,
+// and skip the tasks already recorded: those are not run and their [Outcome] is [Restored].
+// The values of [Node] tasks are saved and restored using [encoding/json].
+//
+// Errors of store.Save do not stop the run, but are returned, annotated with the name of the task.
+func WithCheckpoint(store CheckpointStore) Option {
+	return func(r *Rendezvous) {
+		r.checkpoint = store
+	}
+}
+
+// FileCheckpoint is a [CheckpointStore] persisted as a JSON file at Path. The file
+// is replaced atomically on each save. FileCheckpoint is safe for concurrent use.
+//
+// Use [FileCheckpoint.Clear] to start over.
+type FileCheckpoint struct {
+	Path string
+
+	mu    sync.Mutex
+	tasks map[string]json.RawMessage
+}
+
+// Load implements [CheckpointStore]. A missing file is an empty checkpoint.
+func (c *FileCheckpoint) Load(ctx context.Context) (map[string]json.RawMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	tasks := make(map[string]json.RawMessage, len(c.tasks))
+	for name, output := range c.tasks {
+		tasks[name] = output
+	}
+	return tasks, nil
+}
+
+func (c *FileCheckpoint) load() error {
+	c.tasks = make(map[string]json.RawMessage)
+	b, err := os.ReadFile(c.Path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(b, &c.tasks)
+}
+
+// Save implements [CheckpointStore].
+func (c *FileCheckpoint) Save(ctx context.Context, task string, output json.RawMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tasks == nil {
+		if err := c.load(); err != nil {
+			return err
+		}
+	}
+	if output == nil {
+		output = json.RawMessage("null")
+	}
+	c.tasks[task] = output
+	b, err := json.Marshal(c.tasks)
+	if err != nil {
+		delete(c.tasks, task)
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(c.Path), filepath.Base(c.Path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.Path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// Clear removes the file.
+func (c *FileCheckpoint) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tasks = nil
+	err := os.Remove(c.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,245 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	var runsA, runsB, runsC int32
+	fail := true
+
+	run := func() (*rendezvous.Node[string], rendezvous.Report, error) {
+		var g rendezvous.Graph
+		a := rendezvous.AddNode(&g, "a", func(ctx context.Context) ([]int, error) {
+			atomic.AddInt32(&runsA, 1)
+			return []int{1, 2, 3}, nil
+		})
+		g.Add("b", func(ctx context.Context) error {
+			atomic.AddInt32(&runsB, 1)
+			return nil
+		}, "a")
+		c := rendezvous.AddNode1(&g, "c", a, func(ctx context.Context, a []int) (string, error) {
+			atomic.AddInt32(&runsC, 1)
+			if fail {
+				return "", myErr
+			}
+			return strings.Repeat("x", len(a)), nil
+		})
+		g.Add("d", noErrorCtx, "b", "c")
+
+		var report rendezvous.Report
+		err := rendezvous.New(
+			rendezvous.WithReport(&report),
+			// A new store, as in a new process
+			rendezvous.WithCheckpoint(&rendezvous.FileCheckpoint{Path: path}),
+		).RunGraph(context.Background(), &g)
+		return c, report, err
+	}
+
+	_, report, err := run()
+	if !errors.Is(err, myErr) {
+		t.Fatalf("got %v", err)
+	}
+	checkOutcomes(t, &report, rendezvous.Succeeded, rendezvous.Succeeded, rendezvous.Failed, rendezvous.Skipped)
+
+	fail = false
+	c, report, err := run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutcomes(t, &report, rendezvous.Restored, rendezvous.Restored, rendezvous.Succeeded, rendezvous.Succeeded)
+	if v, ok := c.Value(); !ok || v != "xxx" {
+		t.Errorf("c: got %q, %v", v, ok)
+	}
+	if runsA != 1 || runsB != 1 || runsC != 2 {
+		t.Errorf("runs: a=%d b=%d c=%d", runsA, runsB, runsC)
+	}
+
+	// All done: nothing runs
+	_, report, err = run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutcomes(t, &report, rendezvous.Restored, rendezvous.Restored, rendezvous.Restored, rendezvous.Restored)
+	if runsC != 2 {
+		t.Errorf("c: %d runs", runsC)
+	}
+
+	// Start over
+	if err := (&rendezvous.FileCheckpoint{Path: path}).Clear(); err != nil {
+		t.Fatal(err)
+	}
+	_, report, err = run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutcomes(t, &report, rendezvous.Succeeded, rendezvous.Succeeded, rendezvous.Succeeded, rendezvous.Succeeded)
+}
+
+func checkOutcomes(t *testing.T, report *rendezvous.Report, outcomes ...rendezvous.Outcome) {
+	t.Helper()
+	for i, o := range outcomes {
+		if got := report.Tasks[i].Outcome; got != o {
+			t.Errorf("%s: got %v, expected %v", report.Tasks[i].Name, got, o)
+		}
+	}
+}
+
+func noErrorCtx(context.Context) error {
+	return nil
+}
+
+type failingCheckpoint struct {
+	loadErr, saveErr error
+}
+
+func (c failingCheckpoint) Load(context.Context) (map[string]json.RawMessage, error) {
+	return nil, c.loadErr
+}
+
+func (c failingCheckpoint) Save(context.Context, string, json.RawMessage) error {
+	return c.saveErr
+}
+
+func TestCheckpointErrors(t *testing.T) {
+	t.Parallel()
+
+	var ran bool
+	var g rendezvous.Graph
+	g.Add("a", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	g.Add("b", noErrorCtx, "a")
+
+	err := rendezvous.New(rendezvous.WithCheckpoint(failingCheckpoint{loadErr: myErr})).RunGraph(context.Background(), &g)
+	if !errors.Is(err, myErr) || ran {
+		t.Errorf("got %v, ran: %v", err, ran)
+	}
+
+	// Save errors do not stop the run
+	var report rendezvous.Report
+	err = rendezvous.New(
+		rendezvous.WithReport(&report),
+		rendezvous.WithCheckpoint(failingCheckpoint{saveErr: myErr}),
+	).RunGraph(context.Background(), &g)
+	if !errors.Is(err, myErr) || !strings.HasPrefix(err.Error(), "a: checkpoint: ") {
+		t.Errorf("got %v", err)
+	}
+	checkOutcomes(t, &report, rendezvous.Succeeded, rendezvous.Succeeded)
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &rendezvous.FileCheckpoint{Path: filepath.Join(t.TempDir(), "checkpoint.json")}
+	tasks, err := c.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("got %v", tasks)
+	}
+	if err := c.Save(ctx, "a", json.RawMessage(`{"x":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(ctx, "b", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err = (&rendezvous.FileCheckpoint{Path: c.Path}).Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || string(tasks["a"]) != `{"x":1}` || string(tasks["b"]) != "null" {
+		t.Errorf("got %q", tasks)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// memoryCheckpoint is a read-only CheckpointStore.
+type memoryCheckpoint map[string]json.RawMessage
+
+func (c memoryCheckpoint) Load(context.Context) (map[string]json.RawMessage, error) {
+	return c, nil
+}
+
+func (c memoryCheckpoint) Save(context.Context, string, json.RawMessage) error {
+	return nil
+}
+
+// stragglerTask returns a task that waits until release is closed, or fails after a while.
+func stragglerTask(release <-chan struct{}) rendezvous.TaskCtx {
+	return func(ctx context.Context) error {
+		select {
+		case <-release:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("straggler not notified")
+		}
+	}
+}
+
+func TestCheckpointStragglers(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var g rendezvous.Graph
+	g.Add("a", noErrorCtx)
+	g.Add("b", noErrorCtx, "a")
+	g.Add("c", noErrorCtx)
+	g.Add("d", stragglerTask(release))
+
+	var stragglers []rendezvous.Straggler
+	err := rendezvous.New(
+		rendezvous.WithCheckpoint(memoryCheckpoint{"a": nil, "b": nil}),
+		rendezvous.WithStragglers(rendezvous.StragglerPolicy{
+			Fraction: 0.5,
+			Notify: func(s []rendezvous.Straggler) {
+				stragglers = s
+				close(release)
+			},
+		}),
+	).RunGraph(context.Background(), &g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stragglers) != 1 || stragglers[0].Name != "d" {
+		t.Errorf("got %+v", stragglers)
+	}
+}
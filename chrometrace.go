@@ -0,0 +1,89 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// chromeEvent is an event of the Chrome trace-event format.
+//
+// See https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type chromeEvent struct {
+	Name  string                 `json:"name"`
+	Cat   string                 `json:"cat,omitempty"`
+	Phase string                 `json:"ph"`
+	TS    float64                `json:"ts"` // microseconds
+	Dur   float64                `json:"dur"`
+	PID   int                    `json:"pid"`
+	TID   int                    `json:"tid"`
+	Scope string                 `json:"s,omitempty"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the report in the Chrome trace-event JSON format,
+// viewable with https://ui.perfetto.dev/ or chrome://tracing.
+//
+// The rendez-vous is on thread 0 and each task is on its own thread (index+1).
+// Tasks not launched appear as instant events at the end of the rendez-vous.
+func (rep *Report) WriteChromeTrace(w io.Writer) error {
+	micros := func(t time.Time) float64 {
+		return float64(t.Sub(rep.Start)) / float64(time.Microsecond)
+	}
+
+	name := rep.Name
+	if name == "" {
+		name = "rendezvous"
+	}
+	events := make([]chromeEvent, 0, 2+2*len(rep.Tasks))
+	events = append(events,
+		chromeEvent{Name: "process_name", Phase: "M", PID: 1, Args: map[string]interface{}{"name": name}},
+		chromeEvent{Name: name, Phase: "X", Dur: micros(rep.End), PID: 1},
+	)
+	for i := range rep.Tasks {
+		t := &rep.Tasks[i]
+		tid := t.Index + 1
+		label := t.label()
+		events = append(events, chromeEvent{Name: "thread_name", Phase: "M", PID: 1, TID: tid, Args: map[string]interface{}{"name": label}})
+
+		args := map[string]interface{}{"outcome": t.Outcome.String()}
+		if t.Err != nil {
+			args["error"] = t.Err.Error()
+		}
+		if t.Start.IsZero() {
+			events = append(events, chromeEvent{Name: label, Cat: t.Outcome.String(), Phase: "i", TS: micros(rep.End), PID: 1, TID: tid, Scope: "t", Args: args})
+			continue
+		}
+		events = append(events, chromeEvent{Name: label, Cat: t.Outcome.String(), Phase: "X", TS: micros(t.Start), Dur: micros(t.End) - micros(t.Start), PID: 1, TID: tid, Args: args})
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []chromeEvent `json:"traceEvents"`
+		DisplayTimeUnit string        `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
+
+// label returns the name of the task, or its index.
+func (t *TaskReport) label() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return "task " + strconv.Itoa(t.Index)
+}
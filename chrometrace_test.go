@@ -0,0 +1,70 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestReportWriteChromeTrace(t *testing.T) {
+	t.Parallel()
+
+	var report rendezvous.Report
+	rendezvous.New(
+		rendezvous.WithTaskNames("a"),
+		rendezvous.WithReport(&report),
+	).WaitAll(noError, withError, nil)
+
+	var buf bytes.Buffer
+	if err := report.WriteChromeTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("%s", buf.Bytes())
+
+	var trace struct {
+		TraceEvents []struct {
+			Name  string                 `json:"name"`
+			Phase string                 `json:"ph"`
+			TID   int                    `json:"tid"`
+			Args  map[string]interface{} `json:"args"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	phases := make(map[string]string)
+	for _, ev := range trace.TraceEvents {
+		if ev.Phase != "M" {
+			phases[ev.Name] += ev.Phase
+		}
+	}
+	for name, expected := range map[string]string{
+		"rendezvous": "X",
+		"a":          "X",
+		"task 1":     "X",
+		"task 2":     "i",
+	} {
+		if phases[name] != expected {
+			t.Errorf("%s: got %q, expected %q", name, phases[name], expected)
+		}
+	}
+}
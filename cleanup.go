@@ -0,0 +1,74 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"sync"
+)
+
+// Cleanup registers f to be called when the rendez-vous that runs the task of ctx
+// completes, after all its goroutines are done: this allows to release resources used
+// by sibling tasks. Cleanups are called in the reverse order of registration, and their
+// errors are wrapped into the error returned by [Rendezvous.WaitFirstError] or
+// [Rendezvous.RunGraph] (or added to the errors of [Rendezvous.WaitAll], whose tasks
+// can register cleanups from [Hooks]). A panic is converted to an error.
+//
+// ctx must be the context of a task of a rendez-vous (or derived from it). Cleanup panics otherwise.
+// If the rendez-vous is already complete, f is called immediately and its error is lost.
+func Cleanup(ctx context.Context, f func() error) {
+	t := taskFromContext(ctx)
+	if t == nil || t.cleanups == nil {
+		panic("rendezvous: Cleanup called outside of a task")
+	}
+	if !t.cleanups.add(f) {
+		callTask(ctx, func(context.Context) error { return f() })
+	}
+}
+
+// cleanups is the registry of the cleanups of a rendez-vous.
+type cleanups struct {
+	mu    sync.Mutex
+	funcs []func() error
+	done  bool
+}
+
+// add registers f, and reports false if the cleanups have already run.
+func (c *cleanups) add(f func() error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return false
+	}
+	c.funcs = append(c.funcs, f)
+	return true
+}
+
+// run calls the cleanups in reverse order. The result wraps their errors.
+func (c *cleanups) run() error {
+	c.mu.Lock()
+	funcs := c.funcs
+	c.funcs, c.done = nil, true
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(funcs) - 1; i >= 0; i-- {
+		f := funcs[i]
+		errs = append(errs, callTask(context.Background(), func(context.Context) error { return f() }))
+	}
+	return joinErrors(errs...)
+}
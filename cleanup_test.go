@@ -0,0 +1,128 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestCleanup(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	cleanup := func(name string, err error) func() error {
+		return func() error {
+			log.add(name)
+			return err
+		}
+	}
+	errCleanup := errors.New("cleanup failed")
+
+	started := make(chan struct{})
+	err := rendezvous.WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			rendezvous.Cleanup(ctx, cleanup("a1", nil))
+			rendezvous.Cleanup(ctx, cleanup("a2", errCleanup))
+			close(started)
+			return nil
+		},
+		func(ctx context.Context) error {
+			<-started
+			rendezvous.Cleanup(ctx, func() error {
+				panic("oops")
+			})
+			rendezvous.Cleanup(ctx, cleanup("b", nil))
+			log.add("b done")
+			return nil
+		},
+	)
+	if !errors.Is(err, errCleanup) || err.Error() != "panic: oops\ncleanup failed" {
+		t.Errorf("got %v", err)
+	}
+	checkEvents(t, log.events, []string{"b done", "b", "a2", "a1"})
+}
+
+func TestCleanupGraph(t *testing.T) {
+	t.Parallel()
+
+	// A temporary file produced by a task, consumed by a dependent
+	var g rendezvous.Graph
+	file := rendezvous.AddNode(&g, "produce", func(ctx context.Context) (string, error) {
+		path := filepath.Join(t.TempDir(), "data")
+		if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+			return "", err
+		}
+		rendezvous.Cleanup(ctx, func() error {
+			return os.Remove(path)
+		})
+		return path, nil
+	})
+	rendezvous.AddNode1(&g, "consume", file, func(ctx context.Context, path string) (string, error) {
+		b, err := os.ReadFile(path)
+		return string(b), err
+	})
+	if err := g.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := file.Value()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file not removed: %v", err)
+	}
+}
+
+func TestCleanupOutsideTask(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("panic expected")
+		}
+	}()
+	rendezvous.Cleanup(context.Background(), func() error { return nil })
+}
+
+// cleanupHooks registers a cleanup for each task.
+type cleanupHooks struct {
+	panicHooks
+	log *eventLog
+}
+
+func (h cleanupHooks) OnStart(ctx context.Context, task rendezvous.TaskInfo) context.Context {
+	rendezvous.Cleanup(ctx, func() error {
+		h.log.add(fmt.Sprint("cleanup ", task.Index))
+		return myErr
+	})
+	return ctx
+}
+
+func TestCleanupWaitAll(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	errs := rendezvous.New(rendezvous.WithHooks(cleanupHooks{log: &log})).WaitAll(noError)
+	if len(errs) != 1 || !errors.Is(errs[0], myErr) {
+		t.Errorf("got %v", errs)
+	}
+	checkEvents(t, log.events, []string{"cleanup 0"})
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"sort"
+	"time"
+)
+
+// CriticalPath analyzes a completed run (see [WithReport]) to find the chain of tasks that
+// determined the total wall time: starting from the task that finished last, each step goes
+// back to the dependency that finished last (see [Graph]). For a rendez-vous without
+// dependencies, the path is the task that finished last.
+//
+// The path is in execution order. slack is indexed like report.Tasks: it is the time each
+// task could have been delayed without delaying the end of the run. It is zero for the tasks
+// of the path and for tasks not launched.
+func CriticalPath(report *Report) (path []*TaskReport, slack []time.Duration) {
+	launched := func(t *TaskReport) bool {
+		return t.Outcome == Succeeded || t.Outcome == Failed
+	}
+
+	// Launched tasks, by decreasing end time, so dependents come before their dependencies
+	order := make([]*TaskReport, 0, len(report.Tasks))
+	for i := range report.Tasks {
+		if launched(&report.Tasks[i]) {
+			order = append(order, &report.Tasks[i])
+		}
+	}
+	slack = make([]time.Duration, len(report.Tasks))
+	if len(order) == 0 {
+		return nil, slack
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if !order[i].End.Equal(order[j].End) {
+			return order[i].End.After(order[j].End)
+		}
+		return order[i].Start.After(order[j].Start)
+	})
+
+	// Walk back from the last task through the last finished dependency
+	for t := order[0]; t != nil; {
+		path = append(path, t)
+		var last *TaskReport
+		for _, d := range t.Deps {
+			dep := &report.Tasks[d]
+			if launched(dep) && (last == nil || dep.End.After(last.End)) {
+				last = dep
+			}
+		}
+		t = last
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	// Latest finish time of each task that does not delay its dependents
+	end := order[0].End
+	latestFinish := make([]time.Time, len(report.Tasks))
+	for _, t := range order {
+		latestFinish[t.Index] = end
+	}
+	for _, t := range order {
+		latestStart := latestFinish[t.Index].Add(-t.Duration())
+		for _, d := range t.Deps {
+			if launched(&report.Tasks[d]) && latestStart.Before(latestFinish[d]) {
+				latestFinish[d] = latestStart
+			}
+		}
+		if s := latestFinish[t.Index].Sub(t.End); s > 0 {
+			slack[t.Index] = s
+		}
+	}
+	for _, t := range path {
+		slack[t.Index] = 0
+	}
+
+	return path, slack
+}
@@ -0,0 +1,103 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func sleepTask(d time.Duration) rendezvous.TaskCtx {
+	return func(ctx context.Context) error {
+		time.Sleep(d)
+		return nil
+	}
+}
+
+func pathNames(path []*rendezvous.TaskReport) []string {
+	names := make([]string, len(path))
+	for i, t := range path {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func TestCriticalPathGraph(t *testing.T) {
+	t.Parallel()
+
+	var g rendezvous.Graph
+	g.Add("A", sleepTask(10*time.Millisecond))
+	g.Add("B", sleepTask(50*time.Millisecond), "A")
+	g.Add("C", sleepTask(5*time.Millisecond), "A")
+	g.Add("D", sleepTask(5*time.Millisecond), "B", "C")
+	g.Add("E", sleepTask(5*time.Millisecond))
+
+	var report rendezvous.Report
+	if err := rendezvous.New(rendezvous.WithReport(&report)).RunGraph(context.Background(), &g); err != nil {
+		t.Fatal(err)
+	}
+
+	path, slack := rendezvous.CriticalPath(&report)
+	checkEvents(t, pathNames(path), []string{"A", "B", "D"})
+	for _, task := range path {
+		if slack[task.Index] != 0 {
+			t.Errorf("%s: got slack %v", task.Name, slack[task.Index])
+		}
+	}
+	t.Log(slack)
+	// C could have ended as late as the latest start of D
+	end := report.Tasks[3].End
+	if expected := end.Add(-report.Tasks[3].Duration()).Sub(report.Tasks[2].End); slack[2] != expected || expected <= 0 {
+		t.Errorf("C: got slack %v, expected %v", slack[2], expected)
+	}
+	// E could have ended as late as D
+	if expected := end.Sub(report.Tasks[4].End); slack[4] != expected || expected <= 0 {
+		t.Errorf("E: got slack %v, expected %v", slack[4], expected)
+	}
+}
+
+func TestCriticalPathFanOut(t *testing.T) {
+	t.Parallel()
+
+	var report rendezvous.Report
+	rendezvous.New(
+		rendezvous.WithTaskNames("fast", "slow", "nil"),
+		rendezvous.WithReport(&report),
+	).WaitFirstError(context.Background(),
+		sleepTask(time.Millisecond),
+		sleepTask(20*time.Millisecond),
+		nil,
+	)
+
+	path, slack := rendezvous.CriticalPath(&report)
+	checkEvents(t, pathNames(path), []string{"slow"})
+	if slack[0] != report.Tasks[1].End.Sub(report.Tasks[0].End) || slack[0] <= 0 || slack[2] != 0 {
+		t.Errorf("got %v", slack)
+	}
+}
+
+func TestCriticalPathEmpty(t *testing.T) {
+	t.Parallel()
+
+	path, slack := rendezvous.CriticalPath(&rendezvous.Report{})
+	if path != nil || len(slack) != 0 {
+		t.Errorf("got %v, %v", path, slack)
+	}
+}
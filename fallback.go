@@ -0,0 +1,55 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"time"
+)
+
+// Fallback runs steps one after another until one succeeds.
+//
+// The returned error, if not nil, wraps the errors of every failed step, in order,
+// followed by the error of ctx if its cancellation stopped the chain.
+//
+// Use [Timeout] to give a deadline to a step. Steps must not be nil.
+func Fallback(ctx context.Context, steps ...TaskCtx) error {
+	return FallbackEarly(ctx, -1, steps...)
+}
+
+// FallbackEarly is like [Fallback], but the next step also starts if the running one
+// has not completed after delay (a negative delay disables early start, 0 starts all steps at once).
+// The first success cancels the context of the other running steps.
+// In any case, return happens only after all launched steps are done.
+func FallbackEarly(ctx context.Context, delay time.Duration, steps ...TaskCtx) error {
+	if len(steps) == 0 {
+		return ErrNoTasks
+	}
+	_, _, err := firstSuccess(ctx, len(steps), delay, func(ctx context.Context, i int) (struct{}, error) {
+		return struct{}{}, steps[i](withAttempt(ctx, i))
+	})
+	return err
+}
+
+// Timeout wraps task to run it with a context that has a timeout.
+func Timeout(timeout time.Duration, task TaskCtx) TaskCtx {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return task(ctx)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	var steps []string
+	step := func(name string, err error) rendezvous.TaskCtx {
+		return func(ctx context.Context) error {
+			steps = append(steps, name)
+			return err
+		}
+	}
+
+	err1 := errors.New("cache miss")
+	err := rendezvous.Fallback(context.Background(),
+		step("cache", err1),
+		rendezvous.Timeout(time.Millisecond, func(ctx context.Context) error {
+			steps = append(steps, "regional")
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		step("origin", nil),
+		step("never", nil),
+	)
+	if err != nil {
+		t.Errorf("got %v", err)
+	}
+	if len(steps) != 3 || steps[0] != "cache" || steps[1] != "regional" || steps[2] != "origin" {
+		t.Errorf("got %v", steps)
+	}
+
+	steps = nil
+	err = rendezvous.Fallback(context.Background(), step("a", err1), step("b", myErr))
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(errs) != 2 || errs[0] != err1 || errs[1] != myErr {
+		t.Errorf("got %v", errs)
+	}
+}
+
+func TestFallbackEarly(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	var slowCancelled bool
+	err := rendezvous.FallbackEarly(context.Background(), 10*time.Millisecond,
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			slowCancelled = true
+			return ctx.Err()
+		},
+		func(ctx context.Context) error {
+			return nil
+		},
+	)
+	if err != nil {
+		t.Errorf("got %v", err)
+	}
+	if !slowCancelled {
+		t.Error("slow step must be cancelled and done before return")
+	}
+	if d := time.Since(start); d < 10*time.Millisecond {
+		t.Errorf("second step started too early: %v", d)
+	}
+}
+
+func TestFallbackNone(t *testing.T) {
+	t.Parallel()
+
+	if err := rendezvous.Fallback(context.Background()); err != rendezvous.ErrNoTasks {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldError is an error related to a field of the struct given to [Fill].
+type FieldError struct {
+	Field string // Name of the field, or the key if there is no such field.
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Fill sets concurrently the fields of the struct pointed to by dst, each from the result of
+// a function of fetchers with [WaitFirstError] semantics.
+//
+// The keys of fetchers are the names of exported fields, or the keys given with a struct tag:
+//
+//	type Response struct {
+//		User   *User   `rendezvous:"user"`
+//		Orders []Order `rendezvous:"orders"`
+//		Secret string  `rendezvous:"-"` // Can't be filled.
+//	}
+//
+// The values of fetchers must be functions of type func(context.Context) (T, error), where
+// T is assignable to the type of the field. This is checked before running anything.
+//
+// Fields are set as soon as their function succeeds. The errors of the functions
+// (and of the checks) are wrapped as [*FieldError].
+func Fill(ctx context.Context, dst interface{}, fetchers map[string]interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("rendezvous: Fill: pointer to struct expected, got %T", dst)
+	}
+	v = v.Elem()
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(fetchers))
+	for key := range fetchers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tasks := make([]TaskCtx, 0, len(keys))
+	var errs []error
+	for _, key := range keys {
+		index, ok := fields[key]
+		if !ok {
+			errs = append(errs, &FieldError{Field: key, Err: errors.New("no such field")})
+			continue
+		}
+		sf := v.Type().Field(index)
+		fetch := reflect.ValueOf(fetchers[key])
+		if !isFetcher(fetch, sf.Type) {
+			errs = append(errs, &FieldError{Field: sf.Name, Err: fmt.Errorf("%T is not a func(context.Context) (%s, error)", fetchers[key], sf.Type)})
+			continue
+		}
+		field := v.Field(index)
+		tasks = append(tasks, func(ctx context.Context) error {
+			err := callTask(ctx, func(ctx context.Context) error {
+				out := fetch.Call([]reflect.Value{reflect.ValueOf(ctx)})
+				if err, _ := out[1].Interface().(error); err != nil {
+					return err
+				}
+				field.Set(out[0])
+				return nil
+			})
+			if err != nil {
+				return &FieldError{Field: sf.Name, Err: err}
+			}
+			return nil
+		})
+	}
+	if errs != nil {
+		return joinErrors(errs...)
+	}
+
+	return WaitFirstError(ctx, tasks...)
+}
+
+// structFields returns the indexes of the fields of struct type t that can be filled, by key.
+func structFields(t reflect.Type) (map[string]int, error) {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key := sf.Tag.Get("rendezvous")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = sf.Name
+		}
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("rendezvous: Fill: duplicate key %q in %s", key, t)
+		}
+		fields[key] = i
+	}
+	return fields, nil
+}
+
+// isFetcher reports if fetch is a func(context.Context) (T, error) with T assignable to fieldType.
+func isFetcher(fetch reflect.Value, fieldType reflect.Type) bool {
+	if fetch.Kind() != reflect.Func || fetch.IsNil() {
+		return false
+	}
+	t := fetch.Type()
+	return t.NumIn() == 1 && !t.IsVariadic() && t.In(0) == contextType &&
+		t.NumOut() == 2 && t.Out(1) == errorType && t.Out(0).AssignableTo(fieldType)
+}
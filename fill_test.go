@@ -0,0 +1,128 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+type page struct {
+	User   string   `rendezvous:"user"`
+	Orders []string `rendezvous:"orders"`
+	Count  int
+	Secret string `rendezvous:"-"`
+}
+
+func ExampleFill() {
+	var resp page
+	err := rendezvous.Fill(context.Background(), &resp, map[string]interface{}{
+		"user": func(ctx context.Context) (string, error) {
+			return "gopher", nil
+		},
+		"orders": func(ctx context.Context) ([]string, error) {
+			return []string{"book", "pen"}, nil
+		},
+		"Count": func(ctx context.Context) (int, error) {
+			return 2, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", resp)
+	// Output:
+	// {User:gopher Orders:[book pen] Count:2 Secret:}
+}
+
+func TestFillTypeCheck(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+	var resp page
+	err := rendezvous.Fill(context.Background(), &resp, map[string]interface{}{
+		"user": func(ctx context.Context) (string, error) {
+			called = true
+			return "gopher", nil
+		},
+		"orders": func(ctx context.Context) (string, error) {
+			return "", nil
+		},
+		"Secret": func(ctx context.Context) (string, error) {
+			return "", nil
+		},
+		"Count": func() (int, error) {
+			return 0, nil
+		},
+	})
+	if called {
+		t.Error("nothing should run")
+	}
+	var fieldErr *rendezvous.FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("got %v", err)
+	}
+	checkEvents(t, errorLines(err), []string{
+		"Count: func() (int, error) is not a func(context.Context) (int, error)",
+		"Secret: no such field",
+		"Orders: func(context.Context) (string, error) is not a func(context.Context) ([]string, error)",
+	})
+
+	for _, dst := range []interface{}{nil, resp, (*page)(nil), new(int)} {
+		if err := rendezvous.Fill(context.Background(), dst, nil); err == nil {
+			t.Errorf("%T: error expected", dst)
+		}
+	}
+}
+
+func TestFillFailure(t *testing.T) {
+	t.Parallel()
+
+	var resp page
+	err := rendezvous.Fill(context.Background(), &resp, map[string]interface{}{
+		"user": func(ctx context.Context) (string, error) {
+			return "gopher", nil
+		},
+		"orders": func(ctx context.Context) ([]string, error) {
+			return nil, myErr
+		},
+	})
+	var fieldErr *rendezvous.FieldError
+	if !errors.Is(err, myErr) || !errors.As(err, &fieldErr) || fieldErr.Field != "Orders" {
+		t.Fatalf("got %v", err)
+	}
+	if err.Error() != "Orders: "+myErr.Error() {
+		t.Errorf("got %q", err)
+	}
+	if resp.User != "gopher" {
+		t.Errorf("got %+v", resp)
+	}
+
+	err = rendezvous.Fill(context.Background(), &resp, map[string]interface{}{
+		"Count": func(ctx context.Context) (int, error) {
+			panic("oops")
+		},
+	})
+	if err == nil || err.Error() != "Count: panic: oops" {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Graph is a set of named tasks with dependencies between them: a directed acyclic graph (DAG).
+//
+// The zero value is an empty graph.
+type Graph struct {
+	nodes  []*graphNode
+	byName map[string]int
+	err    error // First error of Add.
+
+	validated bool
+}
+
+type graphNode struct {
+	name       string
+	task       TaskCtx
+	deps       []string
+	depIndexes []int
+	dependents []int
+	reset      func() // Called before each run (see [Node]).
+	// Conversion of the output of the task for checkpoints (see [Node] and [WithCheckpoint]).
+	save    func() (json.RawMessage, error)
+	restore func(json.RawMessage) error
+}
+
+// Add registers task under name. The task will run after the tasks named deps
+// have succeeded. Dependencies may be added later.
+//
+// Errors (duplicate name, nil task) are reported by [Graph.Validate].
+func (g *Graph) Add(name string, task TaskCtx, deps ...string) {
+	g.add(name, task, deps)
+}
+
+func (g *Graph) add(name string, task TaskCtx, deps []string) *graphNode {
+	g.validated = false
+	if g.byName == nil {
+		g.byName = make(map[string]int)
+	}
+	if _, exists := g.byName[name]; exists {
+		g.setErr(fmt.Errorf("rendezvous: graph: duplicate task %q", name))
+		return nil
+	}
+	if task == nil {
+		g.setErr(fmt.Errorf("rendezvous: graph: nil task %q", name))
+	}
+	node := &graphNode{name: name, task: task, deps: deps}
+	g.byName[name] = len(g.nodes)
+	g.nodes = append(g.nodes, node)
+	return node
+}
+
+// setErr records the first error of the construction of g.
+func (g *Graph) setErr(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+// Validate checks that the graph is well formed: no errors from [Graph.Add], no unknown
+// dependencies and no cycles.
+func (g *Graph) Validate() error {
+	if g.validated {
+		return nil
+	}
+	if g.err != nil {
+		return g.err
+	}
+	for _, node := range g.nodes {
+		node.dependents = nil
+	}
+	for i, node := range g.nodes {
+		node.depIndexes = make([]int, len(node.deps))
+		for j, dep := range node.deps {
+			d, ok := g.byName[dep]
+			if !ok {
+				return fmt.Errorf("rendezvous: graph: unknown dependency %q of %q", dep, node.name)
+			}
+			node.depIndexes[j] = d
+			g.nodes[d].dependents = append(g.nodes[d].dependents, i)
+		}
+	}
+	if cycle := g.findCycle(); cycle != nil {
+		return errors.New("rendezvous: graph: cycle: " + strings.Join(cycle, " -> "))
+	}
+	g.validated = true
+	return nil
+}
+
+// findCycle returns the names of the tasks of a cycle, if any.
+func (g *Graph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]uint8, len(g.nodes))
+	var path []int
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			// Extract the cycle from the path
+			var cycle []string
+			for j := len(path) - 1; j >= 0; j-- {
+				if path[j] == i {
+					for _, k := range path[j:] {
+						cycle = append(cycle, g.nodes[k].name)
+					}
+					break
+				}
+			}
+			return append(cycle, g.nodes[i].name)
+		}
+		state[i] = visiting
+		path = append(path, i)
+		for _, d := range g.nodes[i].depIndexes {
+			if cycle := visit(d); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range g.nodes {
+		if cycle := visit(i); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// Run runs the tasks of g, each as soon as its dependencies have succeeded.
+// See [Rendezvous.RunGraph].
+func (g *Graph) Run(ctx context.Context) error {
+	return new(Rendezvous).RunGraph(ctx, g)
+}
+
+// RunGraph validates g (see [Graph.Validate]) and runs its tasks, each in a goroutine
+// as soon as its dependencies have succeeded.
+//
+// The semantics are those of [Rendezvous.WaitFirstError]: the first error cancels the context
+// of the running tasks and no more tasks are launched. The tasks that depend (directly or not)
+// on a failed task are skipped: in the [Report] (see [WithReport]) their [Outcome] is [Skipped].
+// In any case, return happens only after all launched goroutines are done.
+//
+// The names of the tasks (see [WithTaskNames]) and the indexes are those given to [Graph.Add],
+// and the errors of tasks are annotated with their name.
+//
+// The cleanups registered by tasks (see [Cleanup]) are called after all tasks are done.
+//
+// With [WithCheckpoint], the tasks that succeeded in a previous run are not run again.
+func (r *Rendezvous) RunGraph(ctx context.Context, g *Graph) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	n := len(g.nodes)
+
+	gr := *r
+	gr.taskNames = make([]string, n)
+	for i, node := range g.nodes {
+		gr.taskNames[i] = node.name
+		if node.reset != nil {
+			node.reset()
+		}
+	}
+
+	rn := gr.start(n)
+	defer rn.finish()
+	if rn.report != nil {
+		for i, node := range g.nodes {
+			rn.report.Tasks[i].Deps = node.depIndexes
+		}
+	}
+
+	restored := make([]bool, n) // Succeeded in a previous run
+	if gr.checkpoint != nil {
+		outputs, err := gr.checkpoint.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("rendezvous: checkpoint: %w", err)
+		}
+		for i, node := range g.nodes {
+			output, ok := outputs[node.name]
+			if !ok {
+				continue
+			}
+			if node.restore != nil {
+				if err := node.restore(output); err != nil {
+					return fmt.Errorf("%s: checkpoint: %w", node.name, err)
+				}
+			}
+			restored[i] = true
+			if rn.report != nil {
+				rn.report.Tasks[i].Outcome = Restored
+			}
+		}
+	}
+
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Restored tasks never run, and skipped tasks count as finished (see tracker.skipped)
+	stopWatch := rn.watch(n-countTrue(restored), childCtx.Done())
+	defer stopWatch()
+
+	type result struct {
+		index int
+		err   error
+	}
+	results := make(chan result, n)
+	remaining := make([]int, n) // Count of dependencies not yet succeeded
+	skipped := make([]bool, n)
+	running := 0
+
+	launch := func(i int) {
+		if childCtx.Err() != nil {
+			return
+		}
+		rn.launch(i)
+		running++
+		go func() {
+			results <- result{i, rn.runTask(childCtx, i, g.nodes[i].task)}
+		}()
+	}
+	var skip func(i int)
+	skip = func(i int) {
+		for _, d := range g.nodes[i].dependents {
+			if !skipped[d] && !restored[d] {
+				skipped[d] = true
+				if rn.report != nil {
+					rn.report.Tasks[d].Outcome = Skipped
+				}
+				if rn.tracker != nil {
+					rn.tracker.skipped()
+				}
+				skip(d)
+			}
+		}
+	}
+
+	for i, node := range g.nodes {
+		remaining[i] = len(node.deps)
+		for _, d := range node.depIndexes {
+			if restored[d] {
+				remaining[i]--
+			}
+		}
+	}
+	for i := range g.nodes {
+		if remaining[i] == 0 && !restored[i] {
+			launch(i)
+		}
+	}
+
+	var errs []error
+	for running > 0 {
+		res := <-results
+		running--
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", g.nodes[res.index].name, res.err))
+			cancel()
+			skip(res.index)
+			continue
+		}
+		node := g.nodes[res.index]
+		for _, d := range node.dependents {
+			remaining[d]--
+			if remaining[d] == 0 && !skipped[d] && !restored[d] {
+				launch(d)
+			}
+		}
+		if gr.checkpoint != nil {
+			var output json.RawMessage
+			var err error
+			if node.save != nil {
+				output, err = node.save()
+			}
+			if err == nil {
+				err = gr.checkpoint.Save(ctx, node.name, output)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: checkpoint: %w", node.name, err))
+			}
+		}
+	}
+
+	// Context cancelled?
+	if errCtx := ctx.Err(); errCtx != nil {
+		// Report the cancellation if it stopped tasks from being launched,
+		// or if it has probably triggered the failure of tasks.
+		if rn.launched+countTrue(skipped)+countTrue(restored) < n || len(errs) > 0 {
+			errs = append([]error{errCtx}, errs...)
+		}
+	}
+
+	stopWatch()
+	errs = append(errs, rn.notifyErr(), rn.cleanups.run())
+
+	return joinErrors(errs...)
+}
+
+func countTrue(b []bool) int {
+	n := 0
+	for _, v := range b {
+		if v {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,187 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	var g rendezvous.Graph
+	bStarted, cStarted := make(chan struct{}), make(chan struct{})
+	// Added in reverse order to check forward references
+	g.Add("D", func(ctx context.Context) error {
+		log.add("D")
+		return nil
+	}, "B", "C")
+	g.Add("B", func(ctx context.Context) error {
+		// B and C run concurrently
+		close(bStarted)
+		<-cStarted
+		log.add("B")
+		return nil
+	}, "A")
+	g.Add("C", func(ctx context.Context) error {
+		close(cStarted)
+		<-bStarted
+		log.add("C")
+		return nil
+	}, "A")
+	g.Add("A", func(ctx context.Context) error {
+		log.add("A")
+		return nil
+	})
+
+	if err := g.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.events) != 4 || log.events[0] != "A" || log.events[3] != "D" {
+		t.Errorf("got %v", log.events)
+	}
+}
+
+func TestGraphFailure(t *testing.T) {
+	t.Parallel()
+
+	var report rendezvous.Report
+	var g rendezvous.Graph
+	g.Add("A", func(ctx context.Context) error { return nil })
+	g.Add("B", func(ctx context.Context) error { return myErr }, "A")
+	g.Add("C", func(ctx context.Context) error { return nil }, "B")
+	g.Add("D", func(ctx context.Context) error { return nil }, "C", "A")
+	g.Add("E", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := rendezvous.New(rendezvous.WithReport(&report)).RunGraph(context.Background(), &g)
+	if !errors.Is(err, myErr) {
+		t.Errorf("got %v", err)
+	}
+	t.Log(err)
+	if err.Error() != "B: my error\nE: context canceled" && err.Error() != "E: context canceled\nB: my error" {
+		t.Errorf("errors must be annotated with the task name, got %q", err)
+	}
+
+	for i, expected := range []rendezvous.Outcome{
+		rendezvous.Succeeded,
+		rendezvous.Failed,
+		rendezvous.Skipped,
+		rendezvous.Skipped,
+		rendezvous.Failed,
+	} {
+		task := &report.Tasks[i]
+		if task.Outcome != expected {
+			t.Errorf("%s: got %v, expected %v", task.Name, task.Outcome, expected)
+		}
+	}
+	if deps := report.Tasks[3].Deps; len(deps) != 2 || deps[0] != 2 || deps[1] != 0 {
+		t.Errorf("D: deps %v", deps)
+	}
+}
+
+func TestGraphCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var g rendezvous.Graph
+	g.Add("A", func(ctx context.Context) error {
+		panic("should not be launched")
+	})
+	if err := g.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestGraphValidate(t *testing.T) {
+	t.Parallel()
+
+	task := func(ctx context.Context) error { return nil }
+
+	for _, tc := range []struct {
+		name     string
+		build    func(g *rendezvous.Graph)
+		expected string
+	}{
+		{"duplicate", func(g *rendezvous.Graph) {
+			g.Add("A", task)
+			g.Add("A", task)
+		}, `rendezvous: graph: duplicate task "A"`},
+		{"nil", func(g *rendezvous.Graph) {
+			g.Add("A", nil)
+		}, `rendezvous: graph: nil task "A"`},
+		{"unknown", func(g *rendezvous.Graph) {
+			g.Add("A", task, "B")
+		}, `rendezvous: graph: unknown dependency "B" of "A"`},
+		{"cycle", func(g *rendezvous.Graph) {
+			g.Add("A", task)
+			g.Add("B", task, "A", "D")
+			g.Add("C", task, "B")
+			g.Add("D", task, "C")
+		}, `rendezvous: graph: cycle: B -> D -> C -> B`},
+		{"self", func(g *rendezvous.Graph) {
+			g.Add("A", task, "A")
+		}, `rendezvous: graph: cycle: A -> A`},
+	} {
+		var g rendezvous.Graph
+		tc.build(&g)
+		err := g.Validate()
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("%s: got %v, expected %s", tc.name, err, tc.expected)
+		}
+		if err2 := g.Run(context.Background()); err2 == nil || err2.Error() != tc.expected {
+			t.Errorf("%s: Run: got %v", tc.name, err2)
+		}
+	}
+}
+
+func TestGraphStragglersSkipped(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var g rendezvous.Graph
+	g.Add("A", func(ctx context.Context) error { return myErr })
+	g.Add("B", noErrorCtx, "A")
+	g.Add("C", noErrorCtx, "B")
+	g.Add("D", stragglerTask(release))
+
+	var stragglers []rendezvous.Straggler
+	err := rendezvous.New(
+		rendezvous.WithStragglers(rendezvous.StragglerPolicy{
+			Fraction: 0.5,
+			Notify: func(s []rendezvous.Straggler) {
+				stragglers = s
+				close(release)
+			},
+		}),
+	).RunGraph(context.Background(), &g)
+	if err == nil || err.Error() != "A: my error" {
+		t.Errorf("got %v", err)
+	}
+	if len(stragglers) != 1 || stragglers[0].Name != "D" {
+		t.Errorf("got %+v", stragglers)
+	}
+}
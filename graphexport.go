@@ -0,0 +1,165 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportStatus is the status of a task shown in graph exports.
+type exportStatus struct {
+	class    string // succeeded, failed, skipped or cancelled. Empty if unknown.
+	duration string // Empty if not launched.
+}
+
+// exportColors are the fill colors of each class of status.
+var exportColors = map[string]string{
+	"succeeded": "#b8e6b8",
+	"failed":    "#f4a6a6",
+	"skipped":   "#d9d9d9",
+	"cancelled": "#f8d49a",
+}
+
+// exportStatuses returns the status of each task of g in report (which may be nil).
+func (g *Graph) exportStatuses(report *Report) []exportStatus {
+	statuses := make([]exportStatus, len(g.nodes))
+	if report == nil {
+		return statuses
+	}
+	byName := make(map[string]*TaskReport, len(report.Tasks))
+	for i := range report.Tasks {
+		byName[report.Tasks[i].Name] = &report.Tasks[i]
+	}
+	for i, node := range g.nodes {
+		t := byName[node.name]
+		if t == nil {
+			continue
+		}
+		s := &statuses[i]
+		switch t.Outcome {
+		case Succeeded, Restored:
+			s.class = "succeeded"
+		case Failed:
+			if errors.Is(t.Err, context.Canceled) {
+				s.class = "cancelled"
+			} else {
+				s.class = "failed"
+			}
+		case Skipped:
+			s.class = "skipped"
+		case NotLaunched:
+			s.class = "cancelled"
+		}
+		if t.Outcome == Succeeded || t.Outcome == Failed {
+			s.duration = roundDuration(t.Duration()).String()
+		}
+	}
+	return statuses
+}
+
+// roundDuration rounds d to 3 significant digits, for display.
+func roundDuration(d time.Duration) time.Duration {
+	for r := time.Duration(1); r < time.Hour; r *= 10 {
+		if d < 1000*r {
+			return d.Round(r)
+		}
+	}
+	return d.Round(time.Second)
+}
+
+// WriteDOT writes g in the Graphviz DOT language. Edges go from a dependency to its dependents.
+//
+// If report is not nil, it must be a report of the run of g (see [WithReport]): tasks are
+// colored by outcome (succeeded, failed, skipped, cancelled) and annotated with their duration.
+func (g *Graph) WriteDOT(w io.Writer, report *Report) error {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+	statuses := g.exportStatuses(report)
+
+	bw := bufio.NewWriter(w)
+	name := "rendezvous"
+	if report != nil && report.Name != "" {
+		name = report.Name
+	}
+	bw.WriteString(`digraph "` + quote(name) + "\" {\n")
+	bw.WriteString("\tnode [shape=box, style=\"rounded,filled\", fillcolor=white];\n")
+	for i, node := range g.nodes {
+		s := &statuses[i]
+		bw.WriteString(`	"` + quote(node.name) + `"`)
+		if s.class != "" {
+			label := quote(node.name)
+			if s.duration != "" {
+				label += `\n` + s.duration
+			}
+			bw.WriteString(` [label="` + label + `", fillcolor="` + exportColors[s.class] + `", tooltip="` + s.class + `"]`)
+		}
+		bw.WriteString(";\n")
+	}
+	for _, node := range g.nodes {
+		for _, dep := range node.deps {
+			bw.WriteString(`	"` + quote(dep) + `" -> "` + quote(node.name) + "\";\n")
+		}
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// WriteMermaid writes g as a Mermaid flowchart. Edges go from a dependency to its dependents.
+//
+// If report is not nil, it must be a report of the run of g (see [WithReport]): tasks are
+// colored by outcome (succeeded, failed, skipped, cancelled) and annotated with their duration.
+func (g *Graph) WriteMermaid(w io.Writer, report *Report) error {
+	quote := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace
+	statuses := g.exportStatuses(report)
+	id := func(i int) string {
+		return "n" + strconv.Itoa(i)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("flowchart TD\n")
+	classes := make(map[string][]string)
+	for i, node := range g.nodes {
+		s := &statuses[i]
+		label := quote(node.name)
+		if s.duration != "" {
+			label += "<br/>" + s.duration
+		}
+		bw.WriteString("\t" + id(i) + `["` + label + "\"]\n")
+		if s.class != "" {
+			classes[s.class] = append(classes[s.class], id(i))
+		}
+	}
+	for i, node := range g.nodes {
+		for _, dep := range node.deps {
+			if d, ok := g.byName[dep]; ok {
+				bw.WriteString("\t" + id(d) + " --> " + id(i) + "\n")
+			}
+		}
+	}
+	for _, class := range []string{"succeeded", "failed", "skipped", "cancelled"} {
+		if ids := classes[class]; ids != nil {
+			bw.WriteString("\tclassDef " + class + " fill:" + exportColors[class] + "\n")
+			bw.WriteString("\tclass " + strings.Join(ids, ",") + " " + class + "\n")
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,131 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func ExampleGraph_WriteMermaid() {
+	var g rendezvous.Graph
+	task := func(ctx context.Context) error { return nil }
+	g.Add("A", task)
+	g.Add("B", task, "A")
+	g.Add("C", task, "A")
+	g.Add("D", task, "B", "C")
+
+	g.WriteMermaid(os.Stdout, nil)
+	// Output:
+	// flowchart TD
+	// 	n0["A"]
+	// 	n1["B"]
+	// 	n2["C"]
+	// 	n3["D"]
+	// 	n0 --> n1
+	// 	n0 --> n2
+	// 	n1 --> n3
+	// 	n2 --> n3
+}
+
+func ExampleGraph_WriteDOT() {
+	var g rendezvous.Graph
+	task := func(ctx context.Context) error { return nil }
+	g.Add("A", task)
+	g.Add(`"B"`, task, "A")
+
+	g.WriteDOT(os.Stdout, nil)
+	// Output:
+	// digraph "rendezvous" {
+	// 	node [shape=box, style="rounded,filled", fillcolor=white];
+	// 	"A";
+	// 	"\"B\"";
+	// 	"A" -> "\"B\"";
+	// }
+}
+
+// runFailingGraph runs a graph where B fails, C is skipped and D is cancelled.
+func runFailingGraph(t *testing.T) (*rendezvous.Graph, *rendezvous.Report) {
+	var g rendezvous.Graph
+	g.Add("A", func(ctx context.Context) error { return nil })
+	g.Add("B", func(ctx context.Context) error { return myErr }, "A")
+	g.Add("C", func(ctx context.Context) error { return nil }, "B")
+	g.Add("D", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	var report rendezvous.Report
+	if err := rendezvous.New(rendezvous.WithName("failing"), rendezvous.WithReport(&report)).RunGraph(context.Background(), &g); err == nil {
+		t.Fatal("error expected")
+	}
+	return &g, &report
+}
+
+func TestGraphWriteDOTReport(t *testing.T) {
+	t.Parallel()
+
+	g, report := runFailingGraph(t)
+	var buf strings.Builder
+	if err := g.WriteDOT(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	t.Log(out)
+	if !strings.HasPrefix(out, `digraph "failing" {`) {
+		t.Error("graph name expected")
+	}
+	for _, expected := range []string{
+		`"A" [label="A\n`,
+		`tooltip="succeeded"]`,
+		`tooltip="failed"]`,
+		`"C" [label="C", fillcolor="#d9d9d9", tooltip="skipped"]`,
+		`tooltip="cancelled"]`,
+		`"B" -> "C";`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("%s expected", expected)
+		}
+	}
+}
+
+func TestGraphWriteMermaidReport(t *testing.T) {
+	t.Parallel()
+
+	g, report := runFailingGraph(t)
+	var buf strings.Builder
+	if err := g.WriteMermaid(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	t.Log(out)
+	for _, expected := range []string{
+		"\tn0[\"A<br/>",
+		"\tn2[\"C\"]\n",
+		"\tclass n0 succeeded\n",
+		"\tclass n1 failed\n",
+		"\tclass n2 skipped\n",
+		"\tclass n3 cancelled\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("%q expected", expected)
+		}
+	}
+}
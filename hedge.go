@@ -0,0 +1,150 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"time"
+)
+
+// Hedge runs task, and starts another copy of it (up to maxAttempts in total) each time
+// the last copy started has not completed after delay, or as soon as a copy fails.
+// The first success cancels the context of the other copies.
+// A delay <= 0 starts all copies at once.
+//
+// In any case, return happens only after all copies are done.
+//
+// The returned error, if not nil, wraps the errors of all copies, in the order they were started.
+func Hedge(ctx context.Context, delay time.Duration, task TaskCtx, maxAttempts int) error {
+	_, err := HedgeValue(ctx, delay, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, task(ctx)
+	}, maxAttempts)
+	return err
+}
+
+// HedgeValue is like [Hedge], for a function returning a value: the value of the first success is returned.
+func HedgeValue[T any](ctx context.Context, delay time.Duration, fetchT func(context.Context) (T, error), maxAttempts int) (T, error) {
+	if delay < 0 {
+		delay = 0
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	v, _, err := firstSuccess(ctx, maxAttempts, delay, func(ctx context.Context, i int) (T, error) {
+		return fetchT(withAttempt(ctx, i))
+	})
+	return v, err
+}
+
+// firstSuccess runs fn(ctx, i) for i in [0, n) until one succeeds.
+// Attempt i+1 is launched as soon as attempt i fails or, if delay >= 0, when the
+// last launched attempt has not completed after delay (all are launched at once if delay is 0).
+// The first success cancels the context of the others.
+// Return happens only after all launched attempts are done.
+//
+// The result is the value and the index of the first success. If none
+// succeeded, the index is -1 and the error wraps the errors of the attempts in
+// index order, followed by the error of ctx if it stopped launching attempts.
+func firstSuccess[T any](ctx context.Context, n int, delay time.Duration, fn func(context.Context, int) (T, error)) (value T, winner int, err error) {
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		index int
+		value T
+		err   error
+	}
+	results := make(chan result, n)
+	var launched, running int
+
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	launch := func() {
+		i := launched
+		launched++
+		running++
+		go func() {
+			r := result{index: i}
+			defer func() {
+				if p := recover(); p != nil {
+					r.err = panicError(p)
+				}
+				results <- r
+			}()
+			r.value, r.err = fn(childCtx, i)
+		}()
+
+		if delay > 0 && launched < n {
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(delay)
+			timeout = timer.C
+		} else {
+			timeout = nil
+		}
+	}
+
+	errs := make([]error, n+1)
+	winner = -1
+
+	if ctx.Err() == nil {
+		launch()
+		if delay == 0 {
+			for launched < n {
+				launch()
+			}
+		}
+	}
+	for running > 0 {
+		select {
+		case <-timeout:
+			timeout = nil
+			if ctx.Err() == nil {
+				launch()
+			}
+		case r := <-results:
+			running--
+			if r.err != nil {
+				errs[r.index] = r.err
+				if winner < 0 && launched < n && ctx.Err() == nil {
+					launch()
+				}
+			} else if winner < 0 {
+				winner = r.index
+				value = r.value
+				timeout = nil
+				cancel()
+			}
+		}
+	}
+
+	if winner >= 0 {
+		return value, winner, nil
+	}
+	if launched < n {
+		// Launching was stopped by the cancellation of ctx
+		errs[n] = ctx.Err()
+	}
+	return value, -1, joinErrors(errs...)
+}
@@ -0,0 +1,108 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestHedgeValue(t *testing.T) {
+	t.Parallel()
+
+	var attempts, cancelled int32
+	v, err := rendezvous.HedgeValue(context.Background(), 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// The first attempt is stuck until cancelled
+			<-ctx.Done()
+			atomic.AddInt32(&cancelled, 1)
+			return 0, ctx.Err()
+		}
+		return 42, nil
+	}, 2) // A third attempt would be started if the second is slower than delay
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 42 {
+		t.Errorf("got %d", v)
+	}
+	if attempts != 2 {
+		t.Errorf("%d attempts, 2 expected", attempts)
+	}
+	if cancelled != 1 {
+		t.Error("first attempt should have been cancelled before return")
+	}
+}
+
+func TestHedgeFastSuccess(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	err := rendezvous.Hedge(context.Background(), time.Second, func(ctx context.Context) error {
+		atomic.AddInt32(&attempts, 1)
+		return nil
+	}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 1 {
+		t.Errorf("%d attempts, 1 expected", attempts)
+	}
+}
+
+func TestHedgeFailures(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	start := time.Now()
+	err := rendezvous.Hedge(context.Background(), time.Second, func(ctx context.Context) error {
+		if atomic.AddInt32(&attempts, 1) == 2 {
+			panic(myErr)
+		}
+		return errors.New("failure")
+	}, 3)
+	if time.Since(start) >= time.Second {
+		t.Error("attempts should be relaunched immediately on failure")
+	}
+	if attempts != 3 {
+		t.Errorf("%d attempts, 3 expected", attempts)
+	}
+	if !errors.Is(err, myErr) {
+		t.Errorf("got %v", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 3 {
+		t.Errorf("%d errors, 3 expected", n)
+	}
+}
+
+func TestHedgeCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := rendezvous.Hedge(ctx, 0, func(ctx context.Context) error {
+		panic("should not be launched")
+	}, 3)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"time"
+)
+
+// Component is a service managed by a [Lifecycle].
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Lifecycle starts components in dependency order and stops them in reverse order.
+//
+// Components are organized in levels: the components of a level depend on the
+// components of the previous levels. The components of a level are started (and
+// stopped) concurrently.
+//
+// The zero value is an empty Lifecycle.
+type Lifecycle struct {
+	// StopTimeout is the timeout of stopping the started components when Start fails.
+	// With 0, there is no timeout.
+	StopTimeout time.Duration
+
+	levels  [][]Component
+	started [][]bool
+}
+
+// Append adds a level of components that depend on the components of the previous levels.
+func (l *Lifecycle) Append(components ...Component) {
+	l.levels = append(l.levels, components)
+}
+
+// Start starts the levels of components in order. The components of each level are started
+// with [WaitFirstError] semantics: the first failure cancels the context of the others.
+//
+// If a level fails, the components already started are stopped in reverse order
+// (see StopTimeout), and the returned error wraps the start and stop errors.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for _, level := range l.levels[len(l.started):] {
+		started := make([]bool, len(level))
+		l.started = append(l.started, started)
+
+		tasks := make([]TaskCtx, len(level))
+		for i, c := range level {
+			i, c := i, c
+			tasks[i] = func(ctx context.Context) error {
+				if err := c.Start(ctx); err != nil {
+					return err
+				}
+				started[i] = true
+				return nil
+			}
+		}
+		if err := WaitFirstError(ctx, tasks...); err != nil {
+			stopCtx := context.Background()
+			if l.StopTimeout > 0 {
+				var cancel context.CancelFunc
+				stopCtx, cancel = context.WithTimeout(stopCtx, l.StopTimeout)
+				defer cancel()
+			}
+			return joinErrors(err, l.Stop(stopCtx))
+		}
+	}
+	return nil
+}
+
+// Stop stops the started components, level by level in reverse order. The components of
+// each level are stopped concurrently. All levels are stopped, whatever happens.
+//
+// The returned error, if not nil, wraps the errors of all Stop calls.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+	for len(l.started) > 0 {
+		n := len(l.started) - 1
+		level, started := l.levels[n], l.started[n]
+		l.started = l.started[:n]
+
+		tasks := make([]Task, 0, len(level))
+		for i, c := range level {
+			if started[i] {
+				c := c
+				tasks = append(tasks, func() error {
+					return c.Stop(ctx)
+				})
+			}
+		}
+		errs = append(errs, WaitAll(tasks...)...)
+	}
+	return joinErrors(errs...)
+}
@@ -0,0 +1,104 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+type component struct {
+	name     string
+	log      *eventLog
+	startErr error
+	stopErr  error
+}
+
+func (c *component) Start(ctx context.Context) error {
+	if c.startErr != nil {
+		return c.startErr
+	}
+	c.log.add("start " + c.name)
+	return nil
+}
+
+func (c *component) Stop(ctx context.Context) error {
+	c.log.add("stop " + c.name)
+	return c.stopErr
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	var l rendezvous.Lifecycle
+	l.Append(&component{name: "db", log: &log})
+	l.Append(&component{name: "queue", log: &log})
+	l.Append(&component{name: "http", log: &log})
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkEvents(t, log.events, []string{
+		"start db", "start queue", "start http",
+		"stop http", "stop queue", "stop db",
+	})
+}
+
+func TestLifecycleStartFailure(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	stopErr := errors.New("stop failure")
+	var l rendezvous.Lifecycle
+	l.Append(&component{name: "db", log: &log, stopErr: stopErr})
+	l.Append(&component{name: "queue", log: &log})
+	l.Append(&component{name: "http", log: &log, startErr: myErr})
+	l.Append(&component{name: "never", log: &log})
+
+	err := l.Start(context.Background())
+	if !errors.Is(err, myErr) || !errors.Is(err, stopErr) {
+		t.Errorf("got %v", err)
+	}
+	checkEvents(t, log.events, []string{
+		"start db", "start queue",
+		"stop queue", "stop db",
+	})
+
+	// Nothing left to stop
+	if err := l.Stop(context.Background()); err != nil {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Node is a task of a [Graph] that produces a value of type T, which is given
+// to the nodes that use it as input. This generalizes [TaskValueCtx] to graphs.
+//
+// Nodes are created with [AddNode], [AddNode1], [AddNode2] and [AddNode3].
+type Node[T any] struct {
+	graph *Graph
+	name  string
+	value T
+	ok    bool
+}
+
+// Name returns the name of the task of the node in the graph.
+func (n *Node[T]) Name() string {
+	return n.name
+}
+
+// Value returns the value produced by the last run of the graph. ok is false if the
+// task of the node did not succeed.
+func (n *Node[T]) Value() (value T, ok bool) {
+	return n.value, n.ok
+}
+
+func (n *Node[T]) reset() {
+	var zero T
+	n.value, n.ok = zero, false
+}
+
+func (n *Node[T]) save() (json.RawMessage, error) {
+	return json.Marshal(n.value)
+}
+
+func (n *Node[T]) restore(output json.RawMessage) error {
+	if err := json.Unmarshal(output, &n.value); err != nil {
+		return err
+	}
+	n.ok = true
+	return nil
+}
+
+// input is a [Node] of any type.
+type input interface {
+	Name() string
+	graphOf() *Graph
+}
+
+func (n *Node[T]) graphOf() *Graph {
+	return n.graph
+}
+
+func addNode[T any](g *Graph, name string, produceT func(context.Context) (T, error), inputs ...input) *Node[T] {
+	n := &Node[T]{graph: g, name: name}
+	deps := make([]string, len(inputs))
+	for i, in := range inputs {
+		if in.graphOf() != g {
+			g.setErr(fmt.Errorf("rendezvous: graph: input %q of %q belongs to another graph", in.Name(), name))
+		}
+		deps[i] = in.Name()
+	}
+	node := g.add(name, func(ctx context.Context) error {
+		v, err := produceT(ctx)
+		if err != nil {
+			return err
+		}
+		n.value, n.ok = v, true
+		return nil
+	}, deps)
+	if node != nil {
+		node.reset = n.reset
+		node.save = n.save
+		node.restore = n.restore
+	}
+	return n
+}
+
+// AddNode adds to g a node without inputs, whose value is produced by produceT.
+func AddNode[T any](g *Graph, name string, produceT func(context.Context) (T, error)) *Node[T] {
+	return addNode(g, name, produceT)
+}
+
+// AddNode1 adds to g a node whose value is produced by produceT from the value of node a.
+func AddNode1[A, T any](g *Graph, name string, a *Node[A], produceT func(context.Context, A) (T, error)) *Node[T] {
+	return addNode(g, name, func(ctx context.Context) (T, error) {
+		return produceT(ctx, a.value)
+	}, a)
+}
+
+// AddNode2 adds to g a node whose value is produced by produceT from the values of nodes a and b.
+func AddNode2[A, B, T any](g *Graph, name string, a *Node[A], b *Node[B], produceT func(context.Context, A, B) (T, error)) *Node[T] {
+	return addNode(g, name, func(ctx context.Context) (T, error) {
+		return produceT(ctx, a.value, b.value)
+	}, a, b)
+}
+
+// AddNode3 adds to g a node whose value is produced by produceT from the values of nodes a, b and c.
+func AddNode3[A, B, C, T any](g *Graph, name string, a *Node[A], b *Node[B], c *Node[C], produceT func(context.Context, A, B, C) (T, error)) *Node[T] {
+	return addNode(g, name, func(ctx context.Context) (T, error) {
+		return produceT(ctx, a.value, b.value, c.value)
+	}, a, b, c)
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func ExampleAddNode2() {
+	var g rendezvous.Graph
+	user := rendezvous.AddNode(&g, "user", func(ctx context.Context) (string, error) {
+		return "gopher", nil
+	})
+	orders := rendezvous.AddNode1(&g, "orders", user, func(ctx context.Context, user string) (int, error) {
+		return len(user), nil
+	})
+	page := rendezvous.AddNode2(&g, "page", user, orders, func(ctx context.Context, user string, orders int) (string, error) {
+		return fmt.Sprintf("%s has %d orders", user, orders), nil
+	})
+
+	if err := g.Run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(page.Value())
+	// Output:
+	// gopher has 6 orders true
+}
+
+func TestNodeFailure(t *testing.T) {
+	t.Parallel()
+
+	var g rendezvous.Graph
+	a := rendezvous.AddNode(&g, "a", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	b := rendezvous.AddNode(&g, "b", func(ctx context.Context) (int, error) {
+		return 0, myErr
+	})
+	c := rendezvous.AddNode3(&g, "c", a, b, a, func(ctx context.Context, a, b, a2 int) (int, error) {
+		panic("should not run")
+	})
+
+	if err := g.Run(context.Background()); !errors.Is(err, myErr) {
+		t.Errorf("got %v", err)
+	}
+	if v, ok := a.Value(); !ok || v != 1 {
+		t.Errorf("a: got %v, %v", v, ok)
+	}
+	if _, ok := b.Value(); ok {
+		t.Error("b: no value expected")
+	}
+	if _, ok := c.Value(); ok {
+		t.Error("c: no value expected")
+	}
+	if c.Name() != "c" {
+		t.Errorf("c: got name %q", c.Name())
+	}
+}
+
+func TestNodeOtherGraph(t *testing.T) {
+	t.Parallel()
+
+	var g1, g2 rendezvous.Graph
+	a := rendezvous.AddNode(&g1, "a", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	rendezvous.AddNode1(&g2, "b", a, func(ctx context.Context, a int) (int, error) {
+		return a, nil
+	})
+	if err := g2.Validate(); err == nil || !strings.Contains(err.Error(), "another graph") {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"time"
+)
+
+// Rendezvous is a rendez-vous point configured with options.
+//
+// The zero value has no options: its methods behave like the functions of the same name.
+type Rendezvous struct {
+	name       string
+	taskNames  []string
+	hooks      []Hooks
+	report     *Report
+	stragglers *StragglerPolicy
+	grace      time.Duration
+	onGrace    func([]Straggler)
+	checkpoint CheckpointStore
+}
+
+// Option is a setting of a [Rendezvous].
+type Option func(*Rendezvous)
+
+// New returns a [Rendezvous] configured with opts.
+func New(opts ...Option) *Rendezvous {
+	r := new(Rendezvous)
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// WithName sets the name of the rendez-vous, to identify it in logs.
+func WithName(name string) Option {
+	return func(r *Rendezvous) {
+		r.name = name
+	}
+}
+
+// WithTaskNames gives a name to each task, by position in the list of tasks.
+func WithTaskNames(names ...string) Option {
+	return func(r *Rendezvous) {
+		r.taskNames = names
+	}
+}
+
+func (r *Rendezvous) taskName(index int) string {
+	if index < len(r.taskNames) {
+		return r.taskNames[index]
+	}
+	return ""
+}
+
+// WithHooks registers hooks notified of the lifecycle of each task.
+//
+// Hooks are called in the order of registration.
+func WithHooks(hooks Hooks) Option {
+	return func(r *Rendezvous) {
+		r.hooks = append(r.hooks, hooks)
+	}
+}
+
+// TaskInfo identifies a task.
+type TaskInfo struct {
+	Rendezvous string    // Name of the rendez-vous (see [WithName]).
+	Index      int       // Position of the task in the list of tasks.
+	Name       string    // Name of the task (see [WithTaskNames]).
+	Start      time.Time // Start time of the task.
+	// Attempt is the index, starting at 0, of the attempt for a task that runs [Hedge],
+	// [HedgeValue], [Fallback] or [FallbackEarly]: it is set in the context given to each
+	// attempt (see [TaskInfoFromContext]). As it is also 0 for the task itself, use
+	// [AttemptFromContext] to tell the first attempt from a task that is not an attempt.
+	Attempt int
+}
+
+// Hooks are notified of the lifecycle of each task. The methods are called from the goroutine
+// of the task, so they must be safe for concurrent use.
+//
+// OnStart is called in the order of registration of the hooks, while OnPanic, OnCancel and
+// OnEnd are called in reverse order, like deferred calls: the first hook registered wraps
+// the others.
+//
+// A panic in a hook is caught and converted to an error of the task. If OnStart panics,
+// only the hooks whose OnStart has returned are notified of the end of the task.
+//
+// For tasks of [Rendezvous.WaitAll], the ctx derives from [context.Background].
+type Hooks interface {
+	// OnStart is called before the task starts. The returned context, which must
+	// derive from ctx, is the one given to the task and to the other hooks. This
+	// allows for example to start a tracing span.
+	OnStart(ctx context.Context, task TaskInfo) context.Context
+	// OnPanic is called if the task panics, with the panic value.
+	OnPanic(ctx context.Context, task TaskInfo, p interface{})
+	// OnCancel is called if the context of the task has been cancelled when the task returns.
+	OnCancel(ctx context.Context, task TaskInfo, err error)
+	// OnEnd is called last, with the error returned by the task (or converted from a panic).
+	OnEnd(ctx context.Context, task TaskInfo, err error)
+}
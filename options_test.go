@@ -0,0 +1,268 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+type spanKey struct{}
+
+// recordHooks records events as strings.
+type recordHooks struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordHooks) record(task rendezvous.TaskInfo, event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, fmt.Sprintf("%s/%d/%s %s", task.Rendezvous, task.Index, task.Name, event))
+}
+
+func (h *recordHooks) sorted() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events := append([]string(nil), h.events...)
+	sort.Strings(events)
+	return events
+}
+
+func (h *recordHooks) OnStart(ctx context.Context, task rendezvous.TaskInfo) context.Context {
+	if task.Start.IsZero() {
+		panic("Start expected")
+	}
+	h.record(task, "start")
+	return context.WithValue(ctx, spanKey{}, task.Index)
+}
+
+func (h *recordHooks) OnPanic(ctx context.Context, task rendezvous.TaskInfo, p interface{}) {
+	h.record(task, fmt.Sprint("panic ", p))
+}
+
+func (h *recordHooks) OnCancel(ctx context.Context, task rendezvous.TaskInfo, err error) {
+	h.record(task, fmt.Sprint("cancel ", err))
+}
+
+func (h *recordHooks) OnEnd(ctx context.Context, task rendezvous.TaskInfo, err error) {
+	if ctx.Value(spanKey{}) != task.Index {
+		panic("context from OnStart expected")
+	}
+	h.record(task, fmt.Sprint("end ", err))
+}
+
+func checkEvents(t *testing.T, got []string, expected []string) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("got:\n%q\nexpected:\n%q", got, expected)
+	}
+}
+
+func TestHooksWaitAll(t *testing.T) {
+	t.Parallel()
+
+	var hooks recordHooks
+	r := rendezvous.New(
+		rendezvous.WithName("all"),
+		rendezvous.WithTaskNames("a", "b"),
+		rendezvous.WithHooks(&hooks),
+	)
+	r.WaitAll(noError, withStringPanic, nil)
+
+	checkEvents(t, hooks.sorted(), []string{
+		"all/0/a end <nil>",
+		"all/0/a start",
+		"all/1/b end panic: OK",
+		"all/1/b panic OK",
+		"all/1/b start",
+	})
+}
+
+func TestHooksWaitFirstError(t *testing.T) {
+	t.Parallel()
+
+	var hooks recordHooks
+	r := rendezvous.New(rendezvous.WithHooks(&hooks))
+	started := make(chan struct{})
+	r.WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			if ctx.Value(spanKey{}) != 0 {
+				t.Error("context from OnStart expected")
+			}
+			close(started)
+			<-ctx.Done()
+			return nil
+		},
+		func(ctx context.Context) error {
+			<-started
+			return myErr
+		},
+	)
+
+	checkEvents(t, hooks.sorted(), []string{
+		"/0/ cancel context canceled",
+		"/0/ end <nil>",
+		"/0/ start",
+		"/1/ end my error",
+		"/1/ start",
+	})
+}
+
+// panicHooks panics in the hook named panicIn. If detach is set, OnStart returns
+// a context that does not derive from the one given.
+type panicHooks struct {
+	panicIn string
+	detach  bool
+}
+
+func (h panicHooks) hook(name string) {
+	if h.panicIn == name {
+		panic("hook " + name)
+	}
+}
+
+func (h panicHooks) OnStart(ctx context.Context, task rendezvous.TaskInfo) context.Context {
+	h.hook("OnStart")
+	if h.detach {
+		return context.Background()
+	}
+	return ctx
+}
+
+func (h panicHooks) OnPanic(ctx context.Context, task rendezvous.TaskInfo, p interface{}) {
+	h.hook("OnPanic")
+}
+
+func (h panicHooks) OnCancel(ctx context.Context, task rendezvous.TaskInfo, err error) {
+	h.hook("OnCancel")
+}
+
+func (h panicHooks) OnEnd(ctx context.Context, task rendezvous.TaskInfo, err error) {
+	h.hook("OnEnd")
+}
+
+func TestHooksPanic(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		panicIn string
+		events  []string
+	}{
+		{"OnStart", []string{"/0/ end panic: hook OnStart", "/0/ panic hook OnStart", "/0/ start"}},
+		{"OnPanic", []string{"/0/ end panic: task\npanic: hook OnPanic", "/0/ panic task", "/0/ start"}},
+		{"OnCancel", []string{"/0/ cancel context canceled", "/0/ end panic: hook OnCancel", "/0/ start"}},
+		{"OnEnd", []string{"/0/ end panic: hook OnEnd", "/0/ start"}},
+	} {
+		var hooks recordHooks
+		r := rendezvous.New(
+			rendezvous.WithHooks(&hooks),
+			rendezvous.WithHooks(panicHooks{panicIn: tc.panicIn}),
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		var ran bool
+		err := r.WaitFirstError(ctx, func(ctx context.Context) error {
+			ran = true
+			switch tc.panicIn {
+			case "OnPanic":
+				panic("task")
+			case "OnCancel":
+				cancel()
+			}
+			return nil
+		})
+		cancel()
+		if err == nil || !strings.HasSuffix(err.Error(), "panic: hook "+tc.panicIn) {
+			t.Errorf("%s: got %v", tc.panicIn, err)
+		}
+		if ran == (tc.panicIn == "OnStart") {
+			t.Errorf("%s: ran: %v", tc.panicIn, ran)
+		}
+		checkEvents(t, hooks.sorted(), tc.events)
+	}
+}
+
+// orderHooks logs the calls of its methods to check their order.
+type orderHooks struct {
+	name string
+	log  *eventLog
+}
+
+func (h orderHooks) OnStart(ctx context.Context, task rendezvous.TaskInfo) context.Context {
+	h.log.add(h.name + " start")
+	return ctx
+}
+
+func (h orderHooks) OnPanic(ctx context.Context, task rendezvous.TaskInfo, p interface{}) {
+	h.log.add(h.name + " panic")
+}
+
+func (h orderHooks) OnCancel(ctx context.Context, task rendezvous.TaskInfo, err error) {
+	h.log.add(h.name + " cancel")
+}
+
+func (h orderHooks) OnEnd(ctx context.Context, task rendezvous.TaskInfo, err error) {
+	h.log.add(h.name + " end")
+}
+
+func TestHooksOrder(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	r := rendezvous.New(
+		rendezvous.WithHooks(orderHooks{"a", &log}),
+		rendezvous.WithHooks(orderHooks{"b", &log}),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := r.WaitFirstError(ctx, func(context.Context) error {
+		cancel()
+		panic("task")
+	})
+	if err == nil {
+		t.Error("error expected")
+	}
+	checkEvents(t, log.events, []string{
+		"a start", "b start",
+		"b panic", "a panic",
+		"b cancel", "a cancel",
+		"b end", "a end",
+	})
+}
+
+func TestHooksDetachedContext(t *testing.T) {
+	t.Parallel()
+
+	r := rendezvous.New(rendezvous.WithHooks(panicHooks{detach: true}))
+	err := r.WaitFirstError(context.Background(), func(ctx context.Context) error {
+		if _, ok := rendezvous.TaskInfoFromContext(ctx); !ok {
+			t.Error("task info expected")
+		}
+		rendezvous.Cleanup(ctx, func() error { return myErr })
+		return nil
+	})
+	if !errors.Is(err, myErr) {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoTasks is returned by [Race] and [Fallback] if there are no tasks.
+var ErrNoTasks = errors.New("rendezvous: no tasks")
+
+// Race runs each function in a goroutine and returns the value and the index of the first
+// to succeed. The success cancels the context of the others.
+// In any case, return happens only after all launched goroutines are done.
+//
+// If all fail, the index is -1 and the returned error wraps the errors of all functions,
+// in index order.
+//
+// Functions must not be nil.
+func Race[T any](ctx context.Context, fetchT ...func(context.Context) (T, error)) (T, int, error) {
+	if len(fetchT) == 0 {
+		var zero T
+		return zero, -1, ErrNoTasks
+	}
+	return firstSuccess(ctx, len(fetchT), 0, func(ctx context.Context, i int) (T, error) {
+		return fetchT[i](ctx)
+	})
+}
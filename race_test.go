@@ -0,0 +1,91 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func ExampleRace() {
+	cache := func(ctx context.Context) (string, error) {
+		return "", errors.New("cache miss")
+	}
+	primary := func(ctx context.Context) (string, error) {
+		return "value", nil
+	}
+	v, i, err := rendezvous.Race(context.Background(), cache, primary)
+	fmt.Println(v, i, err)
+	// Output:
+	// value 1 <nil>
+}
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	var loserCancelled bool
+	v, i, err := rendezvous.Race(context.Background(),
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			loserCancelled = true
+			return 0, ctx.Err()
+		},
+		func(ctx context.Context) (int, error) {
+			return 2, nil
+		},
+	)
+	if err != nil || v != 2 || i != 1 {
+		t.Errorf("got %v, %d, %v", v, i, err)
+	}
+	if !loserCancelled {
+		t.Error("the loser must be done before return")
+	}
+}
+
+func TestRaceAllFail(t *testing.T) {
+	t.Parallel()
+
+	err1 := errors.New("error 1")
+	_, i, err := rendezvous.Race(context.Background(),
+		func(ctx context.Context) (int, error) {
+			return 0, err1
+		},
+		func(ctx context.Context) (int, error) {
+			panic(myErr)
+		},
+	)
+	if i != -1 {
+		t.Errorf("got index %d", i)
+	}
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(errs) != 2 || errs[0] != err1 || errs[1] != myErr {
+		t.Errorf("got %v", errs)
+	}
+}
+
+func TestRaceNone(t *testing.T) {
+	t.Parallel()
+
+	_, i, err := rendezvous.Race[int](context.Background())
+	if i != -1 || err != rendezvous.ErrNoTasks {
+		t.Errorf("got %d, %v", i, err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Task is just a func returning a runtime error.
@@ -32,6 +33,14 @@ type Task = func() error
 // The result is the unordered list of non-nil errors returned by any task.
 // Panic occuring inside a goroutine are caught and converted as errors.
 func WaitAll(tasks ...Task) []error {
+	return new(Rendezvous).WaitAll(tasks...)
+}
+
+// WaitAll is like the [WaitAll] function, with the options of r.
+func (r *Rendezvous) WaitAll(tasks ...Task) []error {
+	rn := r.start(len(tasks))
+	defer rn.finish()
+
 	if len(tasks) == 0 {
 		return nil
 	}
@@ -39,25 +48,22 @@ func WaitAll(tasks ...Task) []error {
 	errChan := make(chan error, len(tasks))
 	wg.Add(len(tasks))
 
-	for _, t := range tasks {
+	ctx := context.Background()
+	for i, t := range tasks {
 		if t == nil {
 			wg.Done()
 			continue
 		}
-		go func(t Task) {
-			var err error
-			defer func() {
-				if err != nil {
-					errChan <- err
-				}
-				wg.Done()
-			}()
-			defer catchPanicAsError(&err)
-			err = t()
-		}(t)
+		rn.launch(i)
+		go func(i int, t Task) {
+			defer wg.Done()
+			if err := rn.runTask(ctx, i, func(context.Context) error { return t() }); err != nil {
+				errChan <- err
+			}
+		}(i, t)
 	}
 
-	wg.Wait()
+	rn.wait(nil, &wg)
 	close(errChan)
 
 	var errs []error
@@ -66,6 +72,12 @@ func WaitAll(tasks ...Task) []error {
 			errs = append(errs, err)
 		}
 	}
+	if err := rn.notifyErr(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := rn.cleanups.run(); err != nil {
+		errs = append(errs, err)
+	}
 
 	return errs
 }
@@ -76,7 +88,8 @@ type TaskCtx = func(ctx context.Context) error
 // WaitFirstError runs each task in a goroutine and waits for all to terminate.
 //
 // The first error returned by a task triggers the cancellation of the context of the others.
-// In any case, return happens only after all launched goroutines are done.
+// In any case, return happens only after all launched goroutines are done, and
+// after the cleanups registered by tasks with [Cleanup] have been called.
 //
 // The returned error, if not nil, wraps the list of errors, in no particular order. Use this to unwrap:
 //
@@ -89,6 +102,14 @@ type TaskCtx = func(ctx context.Context) error
 //   - if the context is cancelled, there is no builtin way to know which task was launched and succeeded.
 //   - when abort happens, some tasks may not have even been launched.
 func WaitFirstError(ctx context.Context, tasks ...TaskCtx) error {
+	return new(Rendezvous).WaitFirstError(ctx, tasks...)
+}
+
+// WaitFirstError is like the [WaitFirstError] function, with the options of r.
+func (r *Rendezvous) WaitFirstError(ctx context.Context, tasks ...TaskCtx) error {
+	rn := r.start(len(tasks))
+	defer rn.finish()
+
 	childCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -97,7 +118,7 @@ func WaitFirstError(ctx context.Context, tasks ...TaskCtx) error {
 	errChan := make(chan error, len(tasks))
 
 launch:
-	for _, t := range tasks {
+	for i, t := range tasks {
 		if t == nil {
 			continue
 		}
@@ -110,22 +131,18 @@ launch:
 			break launch
 		default:
 			wg.Add(1)
-			go func(t TaskCtx) {
+			rn.launch(i)
+			go func(i int, t TaskCtx) {
 				defer wg.Done()
-				var err error
-				defer func() {
-					if err != nil {
-						errChan <- err
-						cancel()
-					}
-				}()
-				defer catchPanicAsError(&err)
-				err = t(childCtx)
-			}(t)
+				if err := rn.runTask(childCtx, i, t); err != nil {
+					errChan <- err
+					cancel()
+				}
+			}(i, t)
 		}
 	}
 
-	wg.Wait()
+	rn.wait(childCtx.Done(), &wg)
 	close(errChan)
 
 	var errs []error
@@ -156,15 +173,167 @@ launch:
 		errs[0] = errCtx
 	}
 
+	errs = append(errs, rn.notifyErr(), rn.cleanups.run())
+
 	return joinErrors(errs...)
 }
 
-func catchPanicAsError(perr *error) {
-	if p := recover(); p != nil {
-		if e, isError := p.(error); isError {
-			*perr = e
-		} else {
-			*perr = fmt.Errorf("panic: %v", p)
+// run is the state of a single rendez-vous of a [Rendezvous].
+type run struct {
+	*Rendezvous
+	report   *Report
+	tracker  *tracker
+	launched int // Count of launched tasks.
+	cleanups cleanups
+}
+
+// start initializes the state of a rendez-vous of n tasks.
+func (r *Rendezvous) start(n int) *run {
+	return &run{
+		Rendezvous: r,
+		report:     r.startReport(n),
+		tracker:    r.startTracker(),
+	}
+}
+
+func (rn *run) finish() {
+	rn.report.finish()
+}
+
+// wait waits for the goroutines of wg to finish. cancelled is closed
+// when the tasks are cancelled (nil if they are not cancellable).
+func (rn *run) wait(cancelled <-chan struct{}, wg *sync.WaitGroup) {
+	if rn.tracker == nil {
+		wg.Wait()
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	rn.tracker.watch(rn.launched, cancelled, done)
+	<-done
+}
+
+// watch watches in a goroutine the tasks that are launched concurrently with the caller
+// (see tracker.watch), and returns the function to call when the tasks are done.
+// stop may be called more than once.
+func (rn *run) watch(launched int, cancelled <-chan struct{}) (stop func()) {
+	if rn.tracker == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	watcherDone := make(chan struct{})
+	go func() {
+		defer close(watcherDone)
+		rn.tracker.watch(launched, cancelled, done)
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-watcherDone
+		})
+	}
+}
+
+// notifyErr returns the panics of the callbacks of [WithStragglers] and [WithGracePeriod],
+// converted to errors. It must be called once the watch is over.
+func (rn *run) notifyErr() error {
+	if rn.tracker == nil {
+		return nil
+	}
+	return rn.tracker.err
+}
+
+// launch is called from the goroutine of the rendez-vous before launching task index.
+func (rn *run) launch(index int) {
+	rn.launched++
+	if rn.tracker != nil {
+		rn.tracker.launched(TaskInfo{
+			Rendezvous: rn.name,
+			Index:      index,
+			Name:       rn.taskName(index),
+			Start:      time.Now(),
+		})
+	}
+}
+
+// runTask runs task in the current goroutine. A panic is caught and converted
+// to an error. The hooks are notified of the lifecycle of the task, and
+// the outcome is recorded in the report.
+func (rn *run) runTask(ctx context.Context, index int, task TaskCtx) (err error) {
+	info := TaskInfo{
+		Rendezvous: rn.name,
+		Index:      index,
+		Name:       rn.taskName(index),
+		Start:      time.Now(),
+	}
+	if rn.report != nil {
+		defer rn.report.Tasks[index].record(info.Start, &err)
+	}
+	if rn.tracker != nil {
+		rn.tracker.started(index)
+		defer rn.tracker.finished(index)
+	}
+	ctx = withTask(ctx, info, &rn.cleanups)
+	// Only the hooks whose OnStart has returned are notified of the end of the task,
+	// in reverse order
+	var hooks []Hooks
+	defer func() {
+		if p := recover(); p != nil {
+			err = panicError(p)
+			for i := len(hooks) - 1; i >= 0; i-- {
+				h := hooks[i]
+				callHook(&err, func() { h.OnPanic(ctx, info, p) })
+			}
 		}
+		if errCtx := ctx.Err(); errCtx != nil {
+			for i := len(hooks) - 1; i >= 0; i-- {
+				h := hooks[i]
+				callHook(&err, func() { h.OnCancel(ctx, info, errCtx) })
+			}
+		}
+		for i := len(hooks) - 1; i >= 0; i-- {
+			h := hooks[i]
+			callHook(&err, func() { h.OnEnd(ctx, info, err) })
+		}
+	}()
+	for i, h := range rn.hooks {
+		ctx = h.OnStart(ctx, info)
+		hooks = rn.hooks[:i+1]
+		if taskFromContext(ctx) == nil {
+			// The hook has not derived the context: attach the task again
+			ctx = withTask(ctx, info, &rn.cleanups)
+		}
+	}
+	return task(ctx)
+}
+
+// callHook calls hook. A panic is converted to an error joined to *err.
+func callHook(err *error, hook func()) {
+	defer func() {
+		if p := recover(); p != nil {
+			*err = joinErrors(*err, panicError(p))
+		}
+	}()
+	hook()
+}
+
+func panicError(p interface{}) error {
+	if e, isError := p.(error); isError {
+		return e
 	}
+	return fmt.Errorf("panic: %v", p)
+}
+
+// callTask runs task in the current goroutine. A panic is caught and converted to an error.
+func callTask(ctx context.Context, task TaskCtx) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = panicError(p)
+		}
+	}()
+	return task(ctx)
 }
@@ -0,0 +1,118 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"strconv"
+	"time"
+)
+
+// WithReport records the timeline of the rendez-vous in report. The report is
+// overwritten by each call to [Rendezvous.WaitAll] or [Rendezvous.WaitFirstError],
+// so the [Rendezvous] must not be used concurrently.
+func WithReport(report *Report) Option {
+	return func(r *Rendezvous) {
+		r.report = report
+	}
+}
+
+// Report is the timeline of a rendez-vous, recorded with [WithReport].
+type Report struct {
+	Name  string       // Name of the rendez-vous (see [WithName]).
+	Start time.Time    // Time of the call.
+	End   time.Time    // Time of the return.
+	Tasks []TaskReport // One for each task, by index, including nil ones.
+}
+
+// TaskReport is the outcome of a task in a [Report].
+type TaskReport struct {
+	Index   int
+	Name    string // See [WithTaskNames].
+	Outcome Outcome
+	Start   time.Time // Zero if not launched.
+	End     time.Time // Zero if not launched.
+	Err     error     // The error returned by the task, or converted from a panic.
+	Deps    []int     // Indexes of the dependencies of the task (see [Graph]).
+}
+
+// Duration returns the execution time of the task.
+func (t *TaskReport) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
+func (t *TaskReport) record(start time.Time, perr *error) {
+	t.Start = start
+	t.End = time.Now()
+	t.Err = *perr
+	if t.Err == nil {
+		t.Outcome = Succeeded
+	} else {
+		t.Outcome = Failed
+	}
+}
+
+// Outcome is the final state of a task.
+type Outcome uint8
+
+const (
+	NotLaunched Outcome = iota // The task was nil, or not launched because of cancellation.
+	Succeeded
+	Failed
+	Skipped  // A dependency failed (see [Graph]).
+	Restored // Succeeded in a previous run (see [WithCheckpoint]).
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case NotLaunched:
+		return "not launched"
+	case Succeeded:
+		return "succeeded"
+	case Failed:
+		return "failed"
+	case Skipped:
+		return "skipped"
+	case Restored:
+		return "restored"
+	default:
+		return "Outcome(" + strconv.Itoa(int(o)) + ")"
+	}
+}
+
+// startReport initializes the report of r (if any) for a rendez-vous of n tasks.
+func (r *Rendezvous) startReport(n int) *Report {
+	rep := r.report
+	if rep == nil {
+		return nil
+	}
+	*rep = Report{
+		Name:  r.name,
+		Start: time.Now(),
+		Tasks: make([]TaskReport, n),
+	}
+	for i := range rep.Tasks {
+		rep.Tasks[i].Index = i
+		rep.Tasks[i].Name = r.taskName(i)
+	}
+	return rep
+}
+
+func (rep *Report) finish() {
+	if rep != nil {
+		rep.End = time.Now()
+	}
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	var report rendezvous.Report
+	r := rendezvous.New(
+		rendezvous.WithName("report"),
+		rendezvous.WithTaskNames("slow", "fail", "nil"),
+		rendezvous.WithReport(&report),
+	)
+	errs := r.WaitAll(withDelay(10*time.Millisecond, noError), withError, nil)
+	if len(errs) != 1 {
+		t.Fatalf("got %v", errs)
+	}
+
+	if report.Name != "report" || len(report.Tasks) != 3 {
+		t.Fatalf("got %+v", report)
+	}
+	if report.End.Before(report.Start) {
+		t.Error("End < Start")
+	}
+	for i, expected := range []rendezvous.Outcome{rendezvous.Succeeded, rendezvous.Failed, rendezvous.NotLaunched} {
+		task := &report.Tasks[i]
+		if task.Index != i || task.Outcome != expected {
+			t.Errorf("task %d: got %+v, expected %v", i, task, expected)
+		}
+	}
+	if d := report.Tasks[0].Duration(); d < 10*time.Millisecond {
+		t.Errorf("task 0: duration %v", d)
+	}
+	if report.Tasks[1].Err != myErr {
+		t.Errorf("task 1: got %v", report.Tasks[1].Err)
+	}
+	if !report.Tasks[2].Start.IsZero() {
+		t.Error("task 2: zero Start expected")
+	}
+}
+
+func TestReportCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var report rendezvous.Report
+	err := rendezvous.New(rendezvous.WithReport(&report)).WaitFirstError(ctx, func(context.Context) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("error expected")
+	}
+	if len(report.Tasks) != 1 || report.Tasks[0].Outcome != rendezvous.NotLaunched {
+		t.Errorf("got %+v", report)
+	}
+}
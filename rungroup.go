@@ -0,0 +1,91 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import "context"
+
+// RunGroup is a group of actors: the first actor to return, with or without error,
+// interrupts all the others. This is like [github.com/oklog/run.Group].
+//
+// Unlike [WaitFirstError], a nil return also stops the group, which is what is
+// needed for long-running services.
+//
+// The zero value is an empty group.
+type RunGroup struct {
+	actors []actor
+}
+
+type actor struct {
+	execute   TaskCtx
+	interrupt func(error)
+}
+
+// Add registers an actor. When an actor returns, the context of every execute
+// function is cancelled and every interrupt function is called with the error
+// of the first actor. interrupt may be nil if cancellation of the context is
+// enough to stop execute.
+func (g *RunGroup) Add(execute TaskCtx, interrupt func(error)) {
+	g.actors = append(g.actors, actor{execute, interrupt})
+}
+
+// Run runs all actors concurrently, waits for the first to return, interrupts
+// all of them and waits for all to return. Panics are caught and converted to errors.
+//
+// The result is the error returned by the first actor, joined with the panics of
+// interrupt functions.
+func (g *RunGroup) Run() error {
+	if len(g.actors) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, len(g.actors))
+	for _, a := range g.actors {
+		go func(execute TaskCtx) {
+			var err error
+			defer func() {
+				if p := recover(); p != nil {
+					err = panicError(p)
+				}
+				errs <- err
+			}()
+			err = execute(ctx)
+		}(a.execute)
+	}
+
+	err := <-errs
+
+	cancel()
+	var errInterrupt error
+	for _, a := range g.actors {
+		if a.interrupt != nil {
+			interrupt := a.interrupt
+			callHook(&errInterrupt, func() { interrupt(err) })
+		}
+	}
+
+	for i := 1; i < len(g.actors); i++ {
+		<-errs
+	}
+
+	if errInterrupt != nil {
+		return joinErrors(err, errInterrupt)
+	}
+	return err
+}
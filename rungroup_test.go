@@ -0,0 +1,119 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestRunGroupNilStops(t *testing.T) {
+	t.Parallel()
+
+	var g rendezvous.RunGroup
+
+	// Stopped by interrupt
+	stop := make(chan struct{})
+	var interrupted error = myErr
+	g.Add(func(context.Context) error {
+		<-stop
+		return nil
+	}, func(err error) {
+		interrupted = err
+		close(stop)
+	})
+
+	// Stopped by context cancellation
+	var cancelled bool
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		cancelled = true
+		return ctx.Err()
+	}, nil)
+
+	// Returns first, without error
+	g.Add(func(context.Context) error {
+		return nil
+	}, nil)
+
+	if err := g.Run(); err != nil {
+		t.Errorf("got %v", err)
+	}
+	if interrupted != nil {
+		t.Errorf("interrupt: got %v", interrupted)
+	}
+	if !cancelled {
+		t.Error("context cancellation expected")
+	}
+}
+
+func TestRunGroupError(t *testing.T) {
+	t.Parallel()
+
+	var g rendezvous.RunGroup
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, nil)
+	g.Add(withPanicCtx, nil)
+
+	if err := g.Run(); err != myErr {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestRunGroupEmpty(t *testing.T) {
+	t.Parallel()
+
+	var g rendezvous.RunGroup
+	if err := g.Run(); err != nil {
+		t.Errorf("got %v", err)
+	}
+}
+
+func withPanicCtx(context.Context) error {
+	return withPanic()
+}
+
+func TestRunGroupInterruptPanic(t *testing.T) {
+	t.Parallel()
+
+	var g rendezvous.RunGroup
+	g.Add(noErrorCtx, func(error) {
+		panic("interrupt")
+	})
+	stopped := make(chan struct{})
+	var running int32 = 1
+	g.Add(func(ctx context.Context) error {
+		<-stopped
+		atomic.StoreInt32(&running, 0)
+		return nil
+	}, func(error) {
+		close(stopped)
+	})
+
+	err := g.Run()
+	if err == nil || err.Error() != "panic: interrupt" {
+		t.Errorf("got %v", err)
+	}
+	if atomic.LoadInt32(&running) != 0 {
+		t.Error("all actors must have returned")
+	}
+}
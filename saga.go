@@ -0,0 +1,125 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Saga runs steps concurrently and, if one fails, undoes the steps that succeeded
+// by running their compensations.
+//
+// The zero value is an empty Saga.
+type Saga struct {
+	// Parallel runs the compensations concurrently. By default they run one after
+	// the other, in the reverse order of [Saga.Add].
+	Parallel bool
+	// CompensateTimeout is the timeout of running the compensations. With 0, there is no timeout.
+	CompensateTimeout time.Duration
+
+	steps []sagaStep
+}
+
+type sagaStep struct {
+	name       string
+	action     TaskCtx
+	compensate TaskCtx
+}
+
+// Add registers a step: action, and compensate to undo it. compensate may be nil
+// if the action has nothing to undo.
+func (s *Saga) Add(name string, action TaskCtx, compensate TaskCtx) {
+	s.steps = append(s.steps, sagaStep{name: name, action: action, compensate: compensate})
+}
+
+// SagaError is the error of a [Saga] that failed.
+type SagaError struct {
+	Err          error // Errors of the actions, annotated with the names of the steps.
+	Compensation error // Errors of the compensations, annotated with the names of the steps. Nil if all succeeded.
+}
+
+func (e *SagaError) Error() string {
+	if e.Compensation == nil {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + "\ncompensation failed: " + e.Compensation.Error()
+}
+
+// Unwrap gives access to both the errors of the actions and of the compensations.
+func (e *SagaError) Unwrap() error {
+	return joinErrors(e.Err, e.Compensation)
+}
+
+// Run runs the actions of the steps with [WaitFirstError] semantics: the first failure
+// cancels the context of the others. If an action fails, the compensations of the steps
+// whose action succeeded are run, with a context that is not a child of ctx (see
+// CompensateTimeout), and the result is a [*SagaError].
+//
+// The action of a step that failed is not compensated: actions must fail without side effects.
+func (s *Saga) Run(ctx context.Context) error {
+	succeeded := make([]bool, len(s.steps))
+	tasks := make([]TaskCtx, len(s.steps))
+	for i, step := range s.steps {
+		i, step := i, step
+		tasks[i] = func(ctx context.Context) error {
+			if err := callTask(ctx, step.action); err != nil {
+				return fmt.Errorf("%s: %w", step.name, err)
+			}
+			succeeded[i] = true
+			return nil
+		}
+	}
+	err := WaitFirstError(ctx, tasks...)
+	if err == nil {
+		return nil
+	}
+
+	compCtx := context.Background()
+	if s.CompensateTimeout > 0 {
+		var cancel context.CancelFunc
+		compCtx, cancel = context.WithTimeout(compCtx, s.CompensateTimeout)
+		defer cancel()
+	}
+	errs := make([]error, len(s.steps))
+	compensate := func(i int) {
+		if err := callTask(compCtx, s.steps[i].compensate); err != nil {
+			errs[i] = fmt.Errorf("%s: %w", s.steps[i].name, err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		if !succeeded[i] || s.steps[i].compensate == nil {
+			continue
+		}
+		if !s.Parallel {
+			compensate(i)
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			compensate(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return &SagaError{Err: err, Compensation: joinErrors(errs...)}
+}
@@ -0,0 +1,117 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+// sagaSteps adds to s steps that log their action and compensation. The last step
+// fails with fail after the others succeeded, except the step named "slow" that
+// waits for cancellation.
+func sagaSteps(s *rendezvous.Saga, log *eventLog, fail error, names ...string) {
+	var wg sync.WaitGroup
+	for _, name := range names {
+		name := name
+		if name == "slow" {
+			s.Add(name, func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}, func(ctx context.Context) error {
+				log.add("undo " + name)
+				return nil
+			})
+			continue
+		}
+		wg.Add(1)
+		s.Add(name, func(ctx context.Context) error {
+			wg.Done()
+			log.add("do " + name)
+			return nil
+		}, func(ctx context.Context) error {
+			log.add("undo " + name)
+			return nil
+		})
+	}
+	s.Add("last", func(ctx context.Context) error {
+		wg.Wait()
+		return fail
+	}, nil)
+}
+
+func TestSagaSuccess(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	var s rendezvous.Saga
+	sagaSteps(&s, &log, nil, "a", "b")
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(log.events)
+	checkEvents(t, log.events, []string{"do a", "do b"})
+}
+
+func TestSagaCompensate(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	var s rendezvous.Saga
+	sagaSteps(&s, &log, myErr, "a", "b", "slow")
+	err := s.Run(context.Background())
+
+	var sagaErr *rendezvous.SagaError
+	if !errors.As(err, &sagaErr) || !errors.Is(err, myErr) || sagaErr.Compensation != nil {
+		t.Fatalf("got %v", err)
+	}
+	if err.Error() != "last: "+myErr.Error()+"\nslow: context canceled" {
+		t.Errorf("got %q", err)
+	}
+	checkEvents(t, log.events[2:], []string{"undo b", "undo a"})
+}
+
+func TestSagaCompensationFailure(t *testing.T) {
+	t.Parallel()
+
+	errUndo := errors.New("undo failed")
+	var log eventLog
+	s := rendezvous.Saga{Parallel: true}
+	sagaSteps(&s, &log, myErr, "a")
+	s.Add("b", noErrorCtx, func(ctx context.Context) error {
+		return errUndo
+	})
+	s.Add("c", noErrorCtx, func(ctx context.Context) error {
+		panic("oops")
+	})
+	err := s.Run(context.Background())
+
+	var sagaErr *rendezvous.SagaError
+	if !errors.As(err, &sagaErr) || !errors.Is(err, myErr) || !errors.Is(err, errUndo) {
+		t.Fatalf("got %v", err)
+	}
+	t.Log(err)
+	if sagaErr.Compensation.Error() != "b: undo failed\nc: panic: oops" {
+		t.Errorf("got %q", sagaErr.Compensation)
+	}
+	checkEvents(t, log.events, []string{"do a", "undo a"})
+}
@@ -0,0 +1,100 @@
+//go:build go1.20
+
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// SignalError is the cause of the cancellation of the context of tasks by [WaitSignals].
+// Use [context.Cause] to retrieve it.
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return "signal: " + e.Signal.String()
+}
+
+// exit is replaced in tests.
+var exit = os.Exit
+
+// WaitSignals is like [WaitFirstError], for the main function of a process:
+// the context of the tasks is also cancelled on reception of one of signals
+// (default: [os.Interrupt] and [syscall.SIGTERM]), with a [*SignalError] as the cause.
+// If a second signal is received before the tasks are done, the process exits
+// immediately with status 128+signal.
+//
+// If the tasks fail after the reception of a signal, the returned error also wraps
+// the [*SignalError].
+//
+// Use [WithGracePeriod] with [Rendezvous.WaitSignals] to report the tasks still
+// running after a shutdown timeout.
+func WaitSignals(ctx context.Context, signals []os.Signal, tasks ...TaskCtx) error {
+	return new(Rendezvous).WaitSignals(ctx, signals, tasks...)
+}
+
+// WaitSignals is like the [WaitSignals] function, with the options of r.
+func (r *Rendezvous) WaitSignals(ctx context.Context, signals []os.Signal, tasks ...TaskCtx) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, signals...)
+	defer signal.Stop(sigChan)
+
+	done := make(chan struct{})
+	watcherDone := make(chan struct{})
+	go func() {
+		defer close(watcherDone)
+		select {
+		case sig := <-sigChan:
+			cancel(&SignalError{Signal: sig})
+		case <-done:
+			return
+		}
+		select {
+		case sig := <-sigChan:
+			code := 1
+			if s, ok := sig.(syscall.Signal); ok {
+				code = 128 + int(s)
+			}
+			exit(code)
+		case <-done:
+		}
+	}()
+
+	err := r.WaitFirstError(ctx, tasks...)
+	close(done)
+	<-watcherDone
+
+	var sigErr *SignalError
+	if err != nil && errors.As(context.Cause(ctx), &sigErr) {
+		err = joinErrors(sigErr, err)
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+//go:build go1.20 && unix
+
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestWaitSignals(t *testing.T) {
+	// Not parallel: uses process signals and replaces exit
+
+	exitCode := make(chan int, 1)
+	exit = func(code int) {
+		exitCode <- code
+	}
+	defer func() {
+		exit = os.Exit
+	}()
+
+	signals := []os.Signal{syscall.SIGUSR1}
+	started := make(chan struct{})
+	err := WaitSignals(context.Background(), signals,
+		func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			var sigErr *SignalError
+			if !errors.As(context.Cause(ctx), &sigErr) || sigErr.Signal != syscall.SIGUSR1 {
+				t.Errorf("cause: got %v", context.Cause(ctx))
+			}
+			// Second signal: force exit
+			syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+			if code := <-exitCode; code != 128+int(syscall.SIGUSR1) {
+				t.Errorf("exit code: got %d", code)
+			}
+			return ctx.Err()
+		},
+		func(ctx context.Context) error {
+			<-started
+			syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+			return nil
+		},
+	)
+	t.Log(err)
+	var sigErr *SignalError
+	if !errors.As(err, &sigErr) {
+		t.Errorf("SignalError expected")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("context.Canceled expected")
+	}
+}
+
+func TestWaitSignalsNoSignal(t *testing.T) {
+	t.Parallel()
+
+	err := WaitSignals(context.Background(), []os.Signal{syscall.SIGUSR2},
+		func(ctx context.Context) error {
+			return nil
+		},
+	)
+	if err != nil {
+		t.Errorf("got %v", err)
+	}
+}
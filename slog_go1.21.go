@@ -0,0 +1,134 @@
+//go:build go1.21
+
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"time"
+)
+
+// WithLogger logs the lifecycle of each task to logger:
+//   - start and successful completion at level Debug;
+//   - cancellation at level Info;
+//   - failure and panic at level Error.
+//
+// Records have the attributes "rendezvous" (see [WithName]), "task" (the index of the task)
+// and "task_name" (see [WithTaskNames]). Completion records also have "duration" and "error".
+// A task cancelled by its context is logged only once, at level Info, with "duration" and
+// "cause": its failure with the error of the context is not logged again.
+//
+// The logger with the attributes of the task is available to the task with [LoggerFromContext].
+//
+// If logger is nil, [slog.Default] is used.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Rendezvous) {
+		r.hooks = append(r.hooks, slogHooks{logger: logger})
+	}
+}
+
+type loggerKey struct{}
+
+// slogTask is the state of a task stored in its context.
+type slogTask struct {
+	logger   *slog.Logger
+	panicked bool
+}
+
+// LoggerFromContext returns the logger given to the task by [WithLogger], with the
+// attributes of the task. [slog.Default] is returned if there is none.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if t, ok := ctx.Value(loggerKey{}).(*slogTask); ok {
+		return t.logger
+	}
+	return slog.Default()
+}
+
+type slogHooks struct {
+	logger *slog.Logger
+}
+
+// taskLogger returns the logger with the attributes of task.
+func (h slogHooks) taskLogger(task TaskInfo) *slog.Logger {
+	logger := h.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := make([]any, 0, 3)
+	if task.Rendezvous != "" {
+		attrs = append(attrs, slog.String("rendezvous", task.Rendezvous))
+	}
+	attrs = append(attrs, slog.Int("task", task.Index))
+	if task.Name != "" {
+		attrs = append(attrs, slog.String("task_name", task.Name))
+	}
+	return logger.With(attrs...)
+}
+
+// taskState returns the state stored in ctx by OnStart. If another hook has not derived
+// its context from ctx, the state is lost, so a new one is returned.
+func (h slogHooks) taskState(ctx context.Context, task TaskInfo) *slogTask {
+	if t, ok := ctx.Value(loggerKey{}).(*slogTask); ok {
+		return t
+	}
+	return &slogTask{logger: h.taskLogger(task)}
+}
+
+func (h slogHooks) OnStart(ctx context.Context, task TaskInfo) context.Context {
+	logger := h.taskLogger(task)
+	logger.DebugContext(ctx, "task start")
+	return context.WithValue(ctx, loggerKey{}, &slogTask{logger: logger})
+}
+
+func (h slogHooks) OnPanic(ctx context.Context, task TaskInfo, p interface{}) {
+	t := h.taskState(ctx, task)
+	t.panicked = true
+	t.logger.ErrorContext(ctx, "task panic",
+		slog.Any("panic", p),
+		slog.Duration("duration", time.Since(task.Start)),
+		slog.String("stack", string(debug.Stack())),
+	)
+}
+
+func (h slogHooks) OnCancel(ctx context.Context, task TaskInfo, err error) {
+	h.taskState(ctx, task).logger.InfoContext(ctx, "task cancelled",
+		slog.Duration("duration", time.Since(task.Start)),
+		slog.Any("cause", err),
+	)
+}
+
+func (h slogHooks) OnEnd(ctx context.Context, task TaskInfo, err error) {
+	t := h.taskState(ctx, task)
+	if t.panicked {
+		// Already logged by OnPanic
+		return
+	}
+	if errCtx := ctx.Err(); errCtx != nil && errors.Is(err, errCtx) {
+		// Already logged by OnCancel
+		return
+	}
+	d := slog.Duration("duration", time.Since(task.Start))
+	if err != nil {
+		t.logger.ErrorContext(ctx, "task failure", d, slog.Any("error", err))
+	} else {
+		t.logger.DebugContext(ctx, "task done", d)
+	}
+}
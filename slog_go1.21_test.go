@@ -0,0 +1,159 @@
+//go:build go1.21
+
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	r := rendezvous.New(
+		rendezvous.WithName("test"),
+		rendezvous.WithTaskNames("ok", "fail", "cancelled"),
+		rendezvous.WithLogger(logger),
+	)
+	started := make(chan struct{})
+	err := r.WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			rendezvous.LoggerFromContext(ctx).Info("hello")
+			close(started)
+			return nil
+		},
+		func(ctx context.Context) error {
+			<-started
+			return errors.New("failure")
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+
+	var records []map[string]any
+	for dec := json.NewDecoder(&buf); dec.More(); {
+		var rec map[string]any
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatal(err)
+		}
+		t.Log(rec)
+		if rec["rendezvous"] != "test" {
+			t.Errorf("rendezvous attribute expected")
+		}
+		records = append(records, rec)
+	}
+
+	count := make(map[string]int)
+	for _, rec := range records {
+		msg := rec["msg"].(string)
+		count[msg]++
+		switch msg {
+		case "hello":
+			if rec["task"] != float64(0) || rec["task_name"] != "ok" {
+				t.Errorf("task attributes expected in logger from context: %v", rec)
+			}
+		case "task failure":
+			if rec["task"] != float64(1) || rec["task_name"] != "fail" || rec["error"] != "failure" {
+				t.Errorf("unexpected record: %v", rec)
+			}
+		case "task cancelled":
+			if rec["cause"] != context.Canceled.Error() || rec["duration"] == nil {
+				t.Errorf("unexpected record: %v", rec)
+			}
+		}
+		if rec["task_name"] == "cancelled" {
+			// The "ok" task may also be cancelled after it has returned
+			count["cancelled: "+msg]++
+		}
+	}
+	for msg, n := range map[string]int{
+		"task start":   3,
+		"hello":        1,
+		"task done":    1,
+		"task failure": 1,
+
+		"cancelled: task start":     1,
+		"cancelled: task cancelled": 1,
+		"cancelled: task failure":   0,
+	} {
+		if count[msg] != n {
+			t.Errorf("%q: %d records, %d expected", msg, count[msg], n)
+		}
+	}
+}
+
+func TestWithLoggerPanic(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	errs := rendezvous.New(rendezvous.WithLogger(logger)).WaitAll(withStringPanic)
+	if len(errs) != 1 {
+		t.Fatalf("got %v", errs)
+	}
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["msg"] != "task panic" || rec["panic"] != "OK" || rec["stack"] == nil {
+		t.Errorf("unexpected record: %v", rec)
+	}
+}
+
+func TestWithLoggerDetachedContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	// The state of the logger hooks is lost by the next hook
+	err := rendezvous.New(
+		rendezvous.WithLogger(logger),
+		rendezvous.WithHooks(panicHooks{detach: true}),
+	).WaitFirstError(context.Background(), func(ctx context.Context) error {
+		return myErr
+	})
+	if err == nil || err.Error() != myErr.Error() {
+		t.Fatalf("got %v", err)
+	}
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["msg"] != "task failure" || rec["task"] != float64(0) {
+		t.Errorf("unexpected record: %v", rec)
+	}
+}
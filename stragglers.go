@@ -0,0 +1,306 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"bytes"
+	"log"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StragglerPolicy defines when to report the tasks that are slowing down a rendez-vous.
+// See [WithStragglers].
+type StragglerPolicy struct {
+	// Fraction of the launched tasks that must have finished to report the others
+	// as stragglers (0 to disable).
+	Fraction float64
+	// After is the delay since the start of the rendez-vous after which the tasks
+	// still running are stragglers (0 to disable).
+	After time.Duration
+	// Stacks enables the capture of the goroutine stack of each straggler.
+	Stacks bool
+	// Notify is called with the stragglers, ordered by index.
+	// If nil, stragglers are logged with the [log] package.
+	// A panic is converted to an error of the rendez-vous.
+	Notify func([]Straggler)
+}
+
+// Straggler is a task still running when a [StragglerPolicy] triggers.
+type Straggler struct {
+	TaskInfo
+	Elapsed time.Duration // Time since the start of the task.
+	Stack   []byte        // Goroutine stack, if requested by [StragglerPolicy].Stacks.
+}
+
+// WithStragglers reports tasks still running when the first of the thresholds of policy is reached.
+// The report happens at most once per rendez-vous, while the tasks are still running.
+func WithStragglers(policy StragglerPolicy) Option {
+	return func(r *Rendezvous) {
+		r.stragglers = &policy
+	}
+}
+
+// WithGracePeriod gives cancelled tasks of [Rendezvous.WaitFirstError] a grace period to return.
+// Past it, notify is called with the tasks still running, which are not honouring the
+// cancellation of their context (the goroutine stack of each task is captured).
+// If notify is nil, the tasks are logged with the [log] package. A panic of notify
+// is converted to an error of the rendez-vous.
+//
+// The rendez-vous still waits for the termination of all tasks.
+func WithGracePeriod(grace time.Duration, notify func([]Straggler)) Option {
+	return func(r *Rendezvous) {
+		r.grace = grace
+		r.onGrace = notify
+	}
+}
+
+// tracker tracks running tasks of a rendez-vous.
+type tracker struct {
+	policy  *StragglerPolicy // May be nil
+	grace   time.Duration
+	onGrace func([]Straggler)
+	stacks  bool
+	start   time.Time
+
+	mu        sync.Mutex
+	running   map[int]*runningTask
+	nFinished int
+	total     int           // Count of launched tasks, known once launching is over (-1 before).
+	reached   chan struct{} // Closed when policy.Fraction is reached.
+
+	err error // Panics of the notify callbacks. Set only by the goroutine of watch.
+}
+
+type runningTask struct {
+	info        TaskInfo // Start is the launch time.
+	goroutineID int64    // 0 until the goroutine of the task starts.
+}
+
+func (r *Rendezvous) startTracker() *tracker {
+	if r.stragglers == nil && r.grace <= 0 {
+		return nil
+	}
+	return &tracker{
+		policy:  r.stragglers,
+		grace:   r.grace,
+		onGrace: r.onGrace,
+		stacks:  r.grace > 0 || r.stragglers.Stacks,
+		start:   time.Now(),
+		running: make(map[int]*runningTask),
+		total:   -1,
+		reached: make(chan struct{}),
+	}
+}
+
+// launched is called from the goroutine of the rendez-vous.
+func (tr *tracker) launched(info TaskInfo) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.running[info.Index] = &runningTask{info: info}
+}
+
+// started is called from the goroutine of the task.
+func (tr *tracker) started(index int) {
+	if !tr.stacks {
+		return
+	}
+	id := goroutineID()
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.running[index].goroutineID = id
+}
+
+// finished is called from the goroutine of the task.
+func (tr *tracker) finished(index int) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	delete(tr.running, index)
+	tr.nFinished++
+	tr.checkFraction()
+}
+
+// skipped is called from the goroutine of the rendez-vous for a task that will
+// never be launched because a dependency failed (see [Graph]).
+func (tr *tracker) skipped() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.nFinished++
+	tr.checkFraction()
+}
+
+func (tr *tracker) checkFraction() {
+	if tr.policy == nil || tr.policy.Fraction <= 0 || tr.total <= 0 {
+		return
+	}
+	select {
+	case <-tr.reached:
+	default:
+		if float64(tr.nFinished) >= tr.policy.Fraction*float64(tr.total) {
+			close(tr.reached)
+		}
+	}
+}
+
+// watch is called once all tasks are launched. It returns when done is closed.
+// Until then it reports stragglers if a threshold is reached, and tasks still
+// running after the grace period following the closing of cancelled.
+func (tr *tracker) watch(launched int, cancelled <-chan struct{}, done <-chan struct{}) {
+	tr.mu.Lock()
+	tr.total = launched
+	tr.checkFraction()
+	tr.mu.Unlock()
+
+	var timeout, graceOver <-chan time.Time
+	var fraction <-chan struct{}
+	if tr.policy != nil {
+		if tr.policy.After > 0 {
+			timer := time.NewTimer(tr.policy.After - time.Since(tr.start))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		fraction = tr.reached
+	}
+	if tr.grace <= 0 {
+		cancelled = nil
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-timeout:
+		case <-fraction:
+		case <-cancelled:
+			cancelled = nil
+			timer := time.NewTimer(tr.grace)
+			defer timer.Stop()
+			graceOver = timer.C
+			continue
+		case <-graceOver:
+			graceOver = nil
+			tr.notify(tr.onGrace, true)
+			continue
+		}
+		// Straggler thresholds are reported only once
+		timeout, fraction = nil, nil
+		tr.notify(tr.policy.Notify, tr.policy.Stacks)
+	}
+}
+
+// notify calls notify (or logs) with the running tasks, if any.
+// A panic of notify is recorded in tr.err.
+func (tr *tracker) notify(notify func([]Straggler), stacks bool) {
+	stragglers := tr.stragglers(stacks)
+	if len(stragglers) == 0 {
+		return
+	}
+	if notify == nil {
+		notify = logStragglers
+	}
+	callHook(&tr.err, func() { notify(stragglers) })
+}
+
+// stragglers returns the running tasks, ordered by index.
+func (tr *tracker) stragglers(stacks bool) []Straggler {
+	now := time.Now()
+	tr.mu.Lock()
+	stragglers := make([]Straggler, 0, len(tr.running))
+	var ids map[int64]int
+	if stacks {
+		ids = make(map[int64]int, len(tr.running))
+	}
+	for _, t := range tr.running {
+		stragglers = append(stragglers, Straggler{
+			TaskInfo: t.info,
+			Elapsed:  now.Sub(t.info.Start),
+		})
+		if ids != nil && t.goroutineID != 0 {
+			ids[t.goroutineID] = t.info.Index
+		}
+	}
+	tr.mu.Unlock()
+
+	sort.Slice(stragglers, func(i, j int) bool {
+		return stragglers[i].Index < stragglers[j].Index
+	})
+
+	if ids != nil {
+		stacks := goroutineStacks(ids)
+		for i := range stragglers {
+			stragglers[i].Stack = stacks[stragglers[i].Index]
+		}
+	}
+	return stragglers
+}
+
+func logStragglers(stragglers []Straggler) {
+	for _, s := range stragglers {
+		log.Printf("rendezvous %q: task %d %q still running after %v", s.Rendezvous, s.Index, s.Name, s.Elapsed)
+		if s.Stack != nil {
+			log.Printf("%s", s.Stack)
+		}
+	}
+}
+
+// goroutineID returns the id of the current goroutine, parsed from its stack trace.
+func goroutineID() int64 {
+	var buf [64]byte
+	id, _ := parseGoroutineID(buf[:runtime.Stack(buf[:], false)])
+	return id
+}
+
+// parseGoroutineID parses the header of a goroutine stack trace: "goroutine 123 [running]:".
+func parseGoroutineID(stack []byte) (int64, bool) {
+	const prefix = "goroutine "
+	if !bytes.HasPrefix(stack, []byte(prefix)) {
+		return 0, false
+	}
+	stack = stack[len(prefix):]
+	if i := bytes.IndexByte(stack, ' '); i > 0 {
+		stack = stack[:i]
+	}
+	id, err := strconv.ParseInt(string(stack), 10, 64)
+	return id, err == nil
+}
+
+// goroutineStacks captures the stacks of the goroutines whose ids are the keys of ids.
+// The result is indexed by the values of ids.
+func goroutineStacks(ids map[int64]int) map[int][]byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[int][]byte, len(ids))
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if id, ok := parseGoroutineID(stack); ok {
+			if index, ok := ids[id]; ok {
+				stacks[index] = stack
+			}
+		}
+	}
+	return stacks
+}
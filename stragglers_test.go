@@ -0,0 +1,196 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+// slowTask blocks until release is closed.
+func slowTask(release <-chan struct{}) rendezvous.Task {
+	return func() error {
+		<-release
+		return nil
+	}
+}
+
+func TestStragglersFraction(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var stragglers []rendezvous.Straggler
+	r := rendezvous.New(
+		rendezvous.WithTaskNames("fast1", "slow", "fast2"),
+		rendezvous.WithStragglers(rendezvous.StragglerPolicy{
+			Fraction: 0.5,
+			Stacks:   true,
+			Notify: func(s []rendezvous.Straggler) {
+				stragglers = s
+				close(release)
+			},
+		}),
+	)
+	// The fast tasks wait for the slow one to be running, so its stack is available
+	slowStarted := make(chan struct{})
+	fast := func() error {
+		<-slowStarted
+		return nil
+	}
+	slow := slowTask(release)
+	errs := r.WaitAll(fast, func() error {
+		close(slowStarted)
+		return slow()
+	}, fast)
+	checkNil(t, errs)
+
+	if len(stragglers) != 1 {
+		t.Fatalf("got %v", stragglers)
+	}
+	s := stragglers[0]
+	if s.Index != 1 || s.Name != "slow" || s.Elapsed <= 0 {
+		t.Errorf("got %+v", s)
+	}
+	t.Logf("%s", s.Stack)
+	if !bytes.Contains(s.Stack, []byte("slowTask")) {
+		t.Error("stack of slowTask expected")
+	}
+}
+
+func TestStragglersAfter(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var notified int
+	r := rendezvous.New(
+		rendezvous.WithStragglers(rendezvous.StragglerPolicy{
+			After: 20 * time.Millisecond,
+			Notify: func(s []rendezvous.Straggler) {
+				notified++
+				if len(s) != 2 || s[0].Index != 0 || s[1].Index != 2 || s[0].Stack != nil {
+					t.Errorf("got %+v", s)
+				}
+				close(release)
+			},
+		}),
+	)
+	checkNil(t, r.WaitAll(slowTask(release), noError, slowTask(release)))
+	if notified != 1 {
+		t.Errorf("notified %d times", notified)
+	}
+
+	// No stragglers
+	checkNil(t, r.WaitAll(noError))
+	if notified != 1 {
+		t.Errorf("notified %d times", notified)
+	}
+}
+
+func TestGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var stubborn []rendezvous.Straggler
+	r := rendezvous.New(
+		rendezvous.WithTaskNames("stubborn", "polite", "fail"),
+		rendezvous.WithGracePeriod(10*time.Millisecond, func(s []rendezvous.Straggler) {
+			stubborn = s
+			close(release)
+		}),
+	)
+	started := make(chan struct{}, 2)
+	err := r.WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			started <- struct{}{}
+			<-release // ignore ctx
+			return nil
+		},
+		func(ctx context.Context) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		func(ctx context.Context) error {
+			<-started
+			<-started
+			return myErr
+		},
+	)
+	if !errors.Is(err, myErr) {
+		t.Errorf("got %v", err)
+	}
+	if len(stubborn) != 1 || stubborn[0].Name != "stubborn" {
+		t.Fatalf("got %+v", stubborn)
+	}
+	if !bytes.Contains(stubborn[0].Stack, []byte("TestGracePeriod")) {
+		t.Errorf("stack expected, got %s", stubborn[0].Stack)
+	}
+}
+
+func TestStragglersNotifyPanic(t *testing.T) {
+	t.Parallel()
+
+	r := rendezvous.New(rendezvous.WithStragglers(rendezvous.StragglerPolicy{
+		After: time.Millisecond,
+		Notify: func(s []rendezvous.Straggler) {
+			panic("notify")
+		},
+	}))
+	sleep := func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+
+	if err := r.WaitFirstError(context.Background(), sleep); err == nil || err.Error() != "panic: notify" {
+		t.Errorf("WaitFirstError: got %v", err)
+	}
+	if errs := r.WaitAll(func() error { return sleep(nil) }); len(errs) != 1 || errs[0].Error() != "panic: notify" {
+		t.Errorf("WaitAll: got %v", errs)
+	}
+	var g rendezvous.Graph
+	g.Add("sleep", sleep)
+	if err := r.RunGraph(context.Background(), &g); err == nil || err.Error() != "panic: notify" {
+		t.Errorf("RunGraph: got %v", err)
+	}
+}
+
+func TestGracePeriodNotifyPanic(t *testing.T) {
+	t.Parallel()
+
+	r := rendezvous.New(rendezvous.WithGracePeriod(time.Millisecond, func(s []rendezvous.Straggler) {
+		panic("notify")
+	}))
+	err := r.WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond) // Ignore cancellation for a while
+			return nil
+		},
+		func(ctx context.Context) error {
+			return myErr
+		},
+	)
+	if !errors.Is(err, myErr) || !strings.HasSuffix(err.Error(), "\npanic: notify") {
+		t.Errorf("got %v", err)
+	}
+}
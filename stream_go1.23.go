@@ -0,0 +1,89 @@
+//go:build go1.23
+
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"iter"
+)
+
+// Stream returns an iterator that runs each task in a goroutine and yields the index
+// and the error of each task as it completes, in completion order. Nil tasks are ignored.
+//
+// Unlike [WaitFirstError], a failure does not cancel the other tasks. Breaking off the
+// iteration cancels the context of the tasks still running. In any case, the iteration
+// ends only after all launched goroutines are done.
+//
+// The tasks are launched by each iteration. The errors of the cleanups registered by tasks
+// (see [Cleanup]) and the panics of the callbacks of [WithStragglers] and [WithGracePeriod]
+// are yielded last, with index -1, unless the iteration was broken off.
+func Stream(ctx context.Context, tasks ...TaskCtx) iter.Seq2[int, error] {
+	return new(Rendezvous).Stream(ctx, tasks...)
+}
+
+// Stream is like the [Stream] function, with the options of r.
+func (r *Rendezvous) Stream(ctx context.Context, tasks ...TaskCtx) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		rn := r.start(len(tasks))
+		defer rn.finish()
+
+		childCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			index int
+			err   error
+		}
+		results := make(chan result, len(tasks))
+		running := 0
+
+		for i, t := range tasks {
+			if t == nil {
+				continue
+			}
+			rn.launch(i)
+			running++
+			go func(i int, t TaskCtx) {
+				results <- result{i, rn.runTask(childCtx, i, t)}
+			}(i, t)
+		}
+		stopWatch := rn.watch(rn.launched, childCtx.Done())
+		defer stopWatch()
+		// Also on panic of yield
+		defer func() {
+			cancel()
+			for ; running > 0; running-- {
+				<-results
+			}
+			rn.cleanups.run()
+		}()
+
+		for running > 0 {
+			res := <-results
+			running--
+			if !yield(res.index, res.err) {
+				return
+			}
+		}
+		stopWatch()
+		if err := joinErrors(rn.notifyErr(), rn.cleanups.run()); err != nil {
+			yield(-1, err)
+		}
+	}
+}
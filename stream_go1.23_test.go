@@ -0,0 +1,132 @@
+//go:build go1.23
+
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+// chainTasks returns n tasks that complete in reverse order: task i waits for task i+1.
+func chainTasks(n int, errs ...error) []rendezvous.TaskCtx {
+	done := make([]chan struct{}, n+1)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	close(done[n])
+	tasks := make([]rendezvous.TaskCtx, n)
+	for i := range tasks {
+		tasks[i] = func(ctx context.Context) error {
+			defer close(done[i])
+			<-done[i+1]
+			if i < len(errs) {
+				return errs[i]
+			}
+			return nil
+		}
+	}
+	return tasks
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	tasks := chainTasks(3, myErr)
+	tasks = append(tasks, nil)
+	var got []string
+	for i, err := range rendezvous.Stream(context.Background(), tasks...) {
+		got = append(got, fmt.Sprint(i, err))
+	}
+	checkEvents(t, got, []string{"2 <nil>", "1 <nil>", "0 " + myErr.Error()})
+}
+
+func TestStreamBreak(t *testing.T) {
+	t.Parallel()
+
+	var running atomic.Int32
+	slow := func(ctx context.Context) error {
+		running.Add(1)
+		defer running.Add(-1)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	var cleaned atomic.Bool
+	fast := func(ctx context.Context) error {
+		rendezvous.Cleanup(ctx, func() error {
+			cleaned.Store(true)
+			return nil
+		})
+		return nil
+	}
+
+	var report rendezvous.Report
+	seq := rendezvous.New(rendezvous.WithReport(&report)).Stream(context.Background(), slow, fast, slow)
+	for i, err := range seq {
+		if i != 1 || err != nil {
+			t.Errorf("got %d, %v", i, err)
+		}
+		break
+	}
+	if n := running.Load(); n != 0 {
+		t.Errorf("%d tasks still running", n)
+	}
+	if !cleaned.Load() {
+		t.Error("cleanup expected")
+	}
+	if !errors.Is(report.Tasks[0].Err, context.Canceled) || report.Tasks[1].Outcome != rendezvous.Succeeded {
+		t.Errorf("got %+v", report.Tasks)
+	}
+}
+
+func TestStreamCleanupError(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	for i, err := range rendezvous.Stream(context.Background(), func(ctx context.Context) error {
+		rendezvous.Cleanup(ctx, func() error {
+			return myErr
+		})
+		return nil
+	}) {
+		got = append(got, fmt.Sprint(i, err))
+	}
+	checkEvents(t, got, []string{"0 <nil>", "-1 " + myErr.Error()})
+}
+
+func TestStreamNotifyPanic(t *testing.T) {
+	t.Parallel()
+
+	r := rendezvous.New(rendezvous.WithStragglers(rendezvous.StragglerPolicy{
+		After: time.Millisecond,
+		Notify: func(s []rendezvous.Straggler) {
+			panic("notify")
+		},
+	}))
+	var got []string
+	for i, err := range r.Stream(context.Background(), sleepTask(50*time.Millisecond)) {
+		got = append(got, fmt.Sprint(i, err))
+	}
+	checkEvents(t, got, []string{"0 <nil>", "-1 panic: notify"})
+}
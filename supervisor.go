@@ -0,0 +1,241 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RestartStrategy defines which services a [Supervisor] restarts when one fails.
+type RestartStrategy uint8
+
+const (
+	OneForOne  RestartStrategy = iota // Restart only the failed service.
+	OneForAll                         // Stop and restart all services.
+	RestForOne                        // Stop and restart the failed service and the services added after it.
+)
+
+func (s RestartStrategy) String() string {
+	switch s {
+	case OneForOne:
+		return "one-for-one"
+	case OneForAll:
+		return "one-for-all"
+	case RestForOne:
+		return "rest-for-one"
+	default:
+		return "RestartStrategy(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Supervisor runs long-lived services, restarting them when they fail, in the
+// style of Erlang/OTP supervisors.
+//
+// A service fails when it returns an error or panics. A service that returns nil
+// is done and is not restarted. Services stopped by the supervisor (to restart
+// them or on shutdown) see their context cancelled.
+type Supervisor struct {
+	Strategy RestartStrategy
+	// MaxRestarts is the restart intensity: the maximum count of failures within Period.
+	// Beyond, the supervisor gives up. With 0, the first failure stops the supervisor.
+	MaxRestarts int
+	// Period of the restart intensity. With 0, failures are counted over the whole run.
+	Period time.Duration
+	// Backoff returns the delay before restarting services, given the count of failures
+	// within Period. Nil means no delay.
+	Backoff func(failures int) time.Duration
+
+	services []service
+}
+
+type service struct {
+	name string
+	run  TaskCtx
+}
+
+// Add registers a service. Services are started in the order of registration.
+func (s *Supervisor) Add(name string, run TaskCtx) {
+	s.services = append(s.services, service{name: name, run: run})
+}
+
+// serviceState is the state of a service in [Supervisor.Run].
+type serviceState struct {
+	running bool
+	done    bool // Returned nil.
+	pending bool // Waiting to be restarted.
+	cancel  context.CancelFunc
+}
+
+type failure struct {
+	time time.Time
+	err  error
+}
+
+// Run starts the services and supervises them until:
+//   - all services are done: the result is nil;
+//   - ctx is cancelled: the result is the error of ctx;
+//   - the restart intensity is exceeded: the result wraps the failures within Period,
+//     each annotated with the name of the service.
+//
+// In any case, return happens only after all services are stopped.
+func (s *Supervisor) Run(ctx context.Context) error {
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	type exit struct {
+		index int
+		err   error
+	}
+	// At most one goroutine per service is running at any time
+	exits := make(chan exit, len(s.services))
+	states := make([]serviceState, len(s.services))
+	running := 0
+
+	start := func(i int) {
+		svcCtx, cancel := context.WithCancel(runCtx)
+		states[i].running = true
+		states[i].cancel = cancel
+		running++
+		run := s.services[i].run
+		go func() {
+			var err error
+			defer func() {
+				if p := recover(); p != nil {
+					err = panicError(p)
+				}
+				cancel()
+				exits <- exit{i, err}
+			}()
+			err = run(svcCtx)
+		}()
+	}
+
+	var (
+		stopping bool
+		failures []failure
+		errs     []error
+		ctxDone  = ctx.Done()
+		backoff  *time.Timer
+		restart  <-chan time.Time
+	)
+	stop := func() {
+		stopping = true
+		cancelRun()
+		if backoff != nil {
+			backoff.Stop()
+		}
+		restart = nil
+	}
+	// mark marks service i for restart.
+	mark := func(i int) {
+		if states[i].done {
+			return
+		}
+		states[i].pending = true
+		if states[i].running {
+			states[i].cancel()
+		}
+	}
+	// restartReady reports if there are services waiting for restart, and none is still running.
+	restartReady := func() bool {
+		ready := false
+		for i := range states {
+			if states[i].pending {
+				if states[i].running {
+					return false
+				}
+				ready = true
+			}
+		}
+		return ready
+	}
+
+	for i := range s.services {
+		start(i)
+	}
+
+	for running > 0 || (!stopping && restart != nil) {
+		select {
+		case <-ctxDone:
+			ctxDone = nil
+			errs = append(errs, ctx.Err())
+			stop()
+		case <-restart:
+			restart = nil
+			for i := range states {
+				if states[i].pending && !states[i].running {
+					states[i].pending = false
+					start(i)
+				}
+			}
+		case e := <-exits:
+			running--
+			st := &states[e.index]
+			st.running = false
+			if stopping || st.pending {
+				// Stopped by the supervisor
+				break
+			}
+			if e.err == nil {
+				st.done = true
+				break
+			}
+
+			now := time.Now()
+			failures = append(failures, failure{now, fmt.Errorf("%s: %w", s.services[e.index].name, e.err)})
+			if s.Period > 0 {
+				for len(failures) > 0 && now.Sub(failures[0].time) > s.Period {
+					failures = failures[1:]
+				}
+			}
+			if len(failures) > s.MaxRestarts {
+				for _, f := range failures {
+					errs = append(errs, f.err)
+				}
+				stop()
+				break
+			}
+
+			switch s.Strategy {
+			case OneForAll:
+				for i := range states {
+					mark(i)
+				}
+			case RestForOne:
+				for i := e.index; i < len(states); i++ {
+					mark(i)
+				}
+			default:
+				mark(e.index)
+			}
+		}
+
+		if !stopping && restart == nil && restartReady() {
+			var delay time.Duration
+			if s.Backoff != nil {
+				delay = s.Backoff(len(failures))
+			}
+			backoff = time.NewTimer(delay)
+			restart = backoff.C
+		}
+	}
+
+	return joinErrors(errs...)
+}
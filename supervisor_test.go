@@ -0,0 +1,186 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+// startCounter counts the starts of services.
+type startCounter struct {
+	mu     sync.Mutex
+	starts map[string]int
+}
+
+func (c *startCounter) start(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.starts == nil {
+		c.starts = make(map[string]int)
+	}
+	c.starts[name]++
+	return c.starts[name]
+}
+
+func (c *startCounter) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprint(c.starts)
+}
+
+// service fails on its first failures starts, then cancels the supervisor
+// on the next start if stop is not nil, and runs until cancelled.
+func (c *startCounter) service(name string, failures int, stop context.CancelFunc) rendezvous.TaskCtx {
+	return func(ctx context.Context) error {
+		n := c.start(name)
+		if n <= failures {
+			return fmt.Errorf("failure %d", n)
+		}
+		if stop != nil {
+			stop()
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+}
+
+func TestSupervisorStrategies(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		strategy rendezvous.RestartStrategy
+		expected string
+	}{
+		{rendezvous.OneForOne, "map[a:1 b:2 c:1]"},
+		{rendezvous.OneForAll, "map[a:2 b:2 c:2]"},
+		{rendezvous.RestForOne, "map[a:1 b:2 c:2]"},
+	} {
+		tc := tc
+		t.Run(tc.strategy.String(), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var c startCounter
+			s := rendezvous.Supervisor{
+				Strategy:    tc.strategy,
+				MaxRestarts: 1,
+			}
+			ready := make(chan struct{})
+			// a and c wait for b to be restarted before stopping the supervisor
+			waitReady := func(ctx context.Context) error {
+				select {
+				case <-ready:
+					cancel()
+				case <-ctx.Done():
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			s.Add("a", func(ctx context.Context) error {
+				c.start("a")
+				return waitReady(ctx)
+			})
+			s.Add("b", func(ctx context.Context) error {
+				if c.start("b") == 1 {
+					return errors.New("failure")
+				}
+				close(ready)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			s.Add("c", func(ctx context.Context) error {
+				c.start("c")
+				return waitReady(ctx)
+			})
+
+			err := s.Run(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("got %v", err)
+			}
+			if got := c.String(); got != tc.expected {
+				t.Errorf("got %s, expected %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSupervisorGiveUp(t *testing.T) {
+	t.Parallel()
+
+	var c startCounter
+	var backoffs []int
+	s := rendezvous.Supervisor{
+		MaxRestarts: 2,
+		Period:      time.Minute,
+		Backoff: func(failures int) time.Duration {
+			backoffs = append(backoffs, failures)
+			return time.Millisecond
+		},
+	}
+	s.Add("crash", c.service("crash", 10, nil))
+	s.Add("ok", c.service("ok", 0, nil))
+
+	err := s.Run(context.Background())
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	t.Log(err)
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(errs) != 3 {
+		t.Errorf("3 errors expected, got %v", errs)
+	}
+	if !strings.HasPrefix(errs[0].Error(), "crash: ") {
+		t.Errorf("error must be annotated with service name: %q", errs[0])
+	}
+	if fmt.Sprint(backoffs) != "[1 2]" {
+		t.Errorf("backoffs: %v", backoffs)
+	}
+	if got := c.String(); got != "map[crash:3 ok:1]" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestSupervisorDone(t *testing.T) {
+	t.Parallel()
+
+	var s rendezvous.Supervisor
+	s.Add("a", func(context.Context) error { return nil })
+	s.Add("b", func(context.Context) error { return nil })
+	if err := s.Run(context.Background()); err != nil {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestSupervisorPanic(t *testing.T) {
+	t.Parallel()
+
+	var s rendezvous.Supervisor
+	s.Add("panic", func(context.Context) error { panic(myErr) })
+	if err := s.Run(context.Background()); !errors.Is(err, myErr) {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"time"
+)
+
+type taskKey struct{}
+
+type attemptKey struct{}
+
+// taskContext is the value attached to the context of a task.
+type taskContext struct {
+	info     TaskInfo
+	cleanups *cleanups
+}
+
+func withTask(ctx context.Context, info TaskInfo, c *cleanups) context.Context {
+	return context.WithValue(ctx, taskKey{}, &taskContext{info: info, cleanups: c})
+}
+
+func taskFromContext(ctx context.Context) *taskContext {
+	t, _ := ctx.Value(taskKey{}).(*taskContext)
+	return t
+}
+
+// TaskInfoFromContext returns the description of the task that runs with ctx (or a context
+// derived from it). ok is false if ctx is not the context of:
+//   - a task of [Rendezvous.WaitFirstError], [Rendezvous.RunGraph] or [Rendezvous.Stream];
+//   - the hooks of a task, including tasks of [Rendezvous.WaitAll] (see [Hooks]);
+//   - an attempt of [Hedge], [HedgeValue], [Fallback] or [FallbackEarly] called from one of
+//     the above (see [TaskInfo.Attempt]).
+func TaskInfoFromContext(ctx context.Context) (info TaskInfo, ok bool) {
+	if t := taskFromContext(ctx); t != nil {
+		return t.info, true
+	}
+	return TaskInfo{}, false
+}
+
+// AttemptFromContext returns the index (starting at 0) of the attempt of [Hedge], [HedgeValue],
+// [Fallback] or [FallbackEarly] that runs with ctx (or a context derived from it), even if
+// they are not called from a task. ok is false if ctx is not the context of an attempt.
+func AttemptFromContext(ctx context.Context) (attempt int, ok bool) {
+	attempt, ok = ctx.Value(attemptKey{}).(int)
+	return
+}
+
+// withAttempt returns the context for attempt. If ctx is the context of a task, the
+// [TaskInfo] of the task is also attached, with the attempt.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	ctx = context.WithValue(ctx, attemptKey{}, attempt)
+	if parent := taskFromContext(ctx); parent != nil {
+		info := parent.info
+		info.Attempt = attempt
+		info.Start = time.Now()
+		ctx = withTask(ctx, info, parent.cleanups)
+	}
+	return ctx
+}
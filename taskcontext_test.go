@@ -0,0 +1,129 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestTaskInfoFromContext(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := rendezvous.TaskInfoFromContext(context.Background()); ok {
+		t.Error("no task info expected")
+	}
+
+	var log eventLog
+	task := func(ctx context.Context) error {
+		info, ok := rendezvous.TaskInfoFromContext(ctx)
+		if !ok || info.Start.IsZero() {
+			t.Errorf("got %+v, %v", info, ok)
+		}
+		log.add(fmt.Sprintf("%s %d %s %d", info.Rendezvous, info.Index, info.Name, info.Attempt))
+		return nil
+	}
+	err := rendezvous.New(
+		rendezvous.WithName("rdv"),
+		rendezvous.WithTaskNames("a", "b"),
+	).WaitFirstError(context.Background(), task, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(log.events)
+	checkEvents(t, log.events, []string{"rdv 0 a 0", "rdv 1 b 0"})
+
+	log.events = nil
+	var g rendezvous.Graph
+	g.Add("first", task)
+	g.Add("second", task, "first")
+	if err = g.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkEvents(t, log.events, []string{" 0 first 0", " 1 second 0"})
+}
+
+func TestTaskInfoAttempt(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	attempt := func(ctx context.Context) error {
+		info, _ := rendezvous.TaskInfoFromContext(ctx)
+		if n, ok := rendezvous.AttemptFromContext(ctx); !ok || n != info.Attempt {
+			t.Errorf("%s: got attempt %d, %v", info.Name, n, ok)
+		}
+		log.add(fmt.Sprintf("%s %d", info.Name, info.Attempt))
+		return myErr
+	}
+	err := rendezvous.New(rendezvous.WithTaskNames("hedge", "fallback")).WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			if _, ok := rendezvous.AttemptFromContext(ctx); ok {
+				t.Error("a task is not an attempt")
+			}
+			rendezvous.Hedge(ctx, 0, attempt, 3)
+			return nil
+		},
+		func(ctx context.Context) error {
+			rendezvous.Fallback(ctx, attempt, attempt)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(log.events)
+	checkEvents(t, log.events, []string{"fallback 0", "fallback 1", "hedge 0", "hedge 1", "hedge 2"})
+}
+
+func TestTaskInfoAttemptOutsideTask(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	attempt := func(ctx context.Context) (int, error) {
+		if info, ok := rendezvous.TaskInfoFromContext(ctx); ok {
+			t.Errorf("no task info expected: %+v", info)
+		}
+		n, ok := rendezvous.AttemptFromContext(ctx)
+		if !ok {
+			t.Error("attempt expected")
+		}
+		log.add(fmt.Sprint("attempt ", n))
+		if n < 2 {
+			return 0, myErr
+		}
+		return n, nil
+	}
+	v, err := rendezvous.HedgeValue(context.Background(), 0, attempt, 3)
+	if err != nil || v != 2 {
+		t.Errorf("got %v, %v", v, err)
+	}
+	sort.Strings(log.events)
+	checkEvents(t, log.events, []string{"attempt 0", "attempt 1", "attempt 2"})
+
+	// No rendez-vous to run cleanups
+	err = rendezvous.Fallback(context.Background(), func(ctx context.Context) error {
+		rendezvous.Cleanup(ctx, func() error { return nil })
+		return nil
+	})
+	if err == nil || err.Error() != "panic: rendezvous: Cleanup called outside of a task" {
+		t.Errorf("got %v", err)
+	}
+}
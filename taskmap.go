@@ -0,0 +1,99 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+)
+
+// WaitFirstErrorMap is like [WaitFirstError], for tasks identified by a key. Nil tasks are ignored.
+//
+// errs has the errors of the tasks that failed (or panicked), by key. err is like the result of
+// [WaitFirstError], with the errors annotated with their key.
+func WaitFirstErrorMap[K comparable](ctx context.Context, tasks map[K]TaskCtx) (errs map[K]error, err error) {
+	keys := make([]K, 0, len(tasks))
+	list := make([]TaskCtx, 0, len(tasks))
+	for k, task := range tasks {
+		if task != nil {
+			keys = append(keys, k)
+			list = append(list, task)
+		}
+	}
+	taskErrs := make([]error, len(list))
+	wrapped := make([]TaskCtx, len(list))
+	for i := range list {
+		i := i
+		wrapped[i] = func(ctx context.Context) error {
+			if taskErrs[i] = callTask(ctx, list[i]); taskErrs[i] != nil {
+				return fmt.Errorf("%v: %w", keys[i], taskErrs[i])
+			}
+			return nil
+		}
+	}
+	err = WaitFirstError(ctx, wrapped...)
+	return errorsByKey(keys, taskErrs), err
+}
+
+// WaitAllValuesMap is like [WaitAll], for functions that return a value, identified by a key.
+// Nil functions are ignored.
+//
+// values has the values of the functions that succeeded, and errs the errors of the others
+// (panics are converted to errors), by key. errs is nil if all succeeded.
+func WaitAllValuesMap[K comparable, V any](fetch map[K]func() (V, error)) (values map[K]V, errs map[K]error) {
+	keys := make([]K, 0, len(fetch))
+	tasks := make([]Task, 0, len(fetch))
+	vals := make([]V, len(fetch))
+	taskErrs := make([]error, len(fetch))
+	for k, fetchV := range fetch {
+		if fetchV == nil {
+			continue
+		}
+		i, fetchV := len(keys), fetchV
+		keys = append(keys, k)
+		tasks = append(tasks, func() error {
+			taskErrs[i] = callTask(context.Background(), func(context.Context) (err error) {
+				vals[i], err = fetchV()
+				return
+			})
+			return nil
+		})
+	}
+	WaitAll(tasks...)
+
+	values = make(map[K]V, len(keys))
+	for i, k := range keys {
+		if taskErrs[i] == nil {
+			values[k] = vals[i]
+		}
+	}
+	return values, errorsByKey(keys, taskErrs)
+}
+
+// errorsByKey returns the map of the non-nil errors by key, or nil.
+func errorsByKey[K comparable](keys []K, errs []error) map[K]error {
+	var m map[K]error
+	for i, err := range errs {
+		if err != nil {
+			if m == nil {
+				m = make(map[K]error)
+			}
+			m[keys[i]] = err
+		}
+	}
+	return m
+}
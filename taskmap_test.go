@@ -0,0 +1,101 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func ExampleWaitAllValuesMap() {
+	values, errs := rendezvous.WaitAllValuesMap(map[string]func() (int, error){
+		"eu": func() (int, error) { return 3, nil },
+		"us": func() (int, error) { return 5, nil },
+		"ap": func() (int, error) { return 0, errors.New("unreachable") },
+	})
+	fmt.Println(values["eu"], values["us"], len(values))
+	fmt.Println(errs)
+	// Output:
+	// 3 5 2
+	// map[ap:unreachable]
+}
+
+func TestWaitFirstErrorMap(t *testing.T) {
+	t.Parallel()
+
+	errs, err := rendezvous.WaitFirstErrorMap(context.Background(), map[string]rendezvous.TaskCtx{
+		"a":   noErrorCtx,
+		"b":   noErrorCtx,
+		"nil": nil,
+	})
+	if errs != nil || err != nil {
+		t.Fatalf("got %v, %v", errs, err)
+	}
+
+	errsByShard, err := rendezvous.WaitFirstErrorMap(context.Background(), map[int]rendezvous.TaskCtx{
+		1: noErrorCtx,
+		2: func(ctx context.Context) error {
+			return myErr
+		},
+		3: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		4: func(ctx context.Context) error {
+			<-ctx.Done()
+			panic("oops")
+		},
+	})
+	if len(errsByShard) != 3 || errsByShard[2] != myErr || !errors.Is(errsByShard[3], context.Canceled) || errsByShard[4].Error() != "panic: oops" {
+		t.Errorf("got %v", errsByShard)
+	}
+	if !errors.Is(err, myErr) {
+		t.Fatalf("got %v", err)
+	}
+	lines := errorLines(err)
+	sort.Strings(lines)
+	checkEvents(t, lines, []string{"2: " + myErr.Error(), "3: context canceled", "4: panic: oops"})
+}
+
+func errorLines(err error) []string {
+	var lines []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		lines = append(lines, e.Error())
+	}
+	return lines
+}
+
+func TestWaitAllValuesMapPanic(t *testing.T) {
+	t.Parallel()
+
+	values, errs := rendezvous.WaitAllValuesMap(map[string]func() (string, error){
+		"ok":    func() (string, error) { return "value", nil },
+		"panic": func() (string, error) { panic("oops") },
+		"nil":   nil,
+	})
+	if len(values) != 1 || values["ok"] != "value" {
+		t.Errorf("got %v", values)
+	}
+	if len(errs) != 1 || errs["panic"] == nil {
+		t.Errorf("got %v", errs)
+	}
+}
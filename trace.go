@@ -0,0 +1,90 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+)
+
+// WithRuntimeTrace attributes the work of each task in execution traces and profiles:
+//   - each task runs in a [runtime/trace.Task] of type the name of the rendez-vous
+//     (or "rendezvous") and in a region named after the task (or its index), so
+//     tasks appear in "go tool trace";
+//   - the goroutine of each task has the [runtime/pprof] labels "rendezvous",
+//     "task" (the index) and "task_name", usable with "pprof -tagfocus".
+//     The labels are inherited by goroutines started by the task.
+func WithRuntimeTrace() Option {
+	return WithHooks(runtimeTraceHooks{})
+}
+
+type runtimeTraceKey struct{}
+
+type runtimeTrace struct {
+	task   *trace.Task
+	region *trace.Region
+}
+
+type runtimeTraceHooks struct{}
+
+func (runtimeTraceHooks) OnStart(ctx context.Context, task TaskInfo) context.Context {
+	labels := []string{"task", strconv.Itoa(task.Index)}
+	if task.Rendezvous != "" {
+		labels = append(labels, "rendezvous", task.Rendezvous)
+	}
+	if task.Name != "" {
+		labels = append(labels, "task_name", task.Name)
+	}
+	ctx = pprof.WithLabels(ctx, pprof.Labels(labels...))
+	// The goroutine is dedicated to the task, so there is no need to restore the labels.
+	pprof.SetGoroutineLabels(ctx)
+
+	taskType := task.Rendezvous
+	if taskType == "" {
+		taskType = "rendezvous"
+	}
+	regionType := task.Name
+	if regionType == "" {
+		regionType = "task " + strconv.Itoa(task.Index)
+	}
+	var t runtimeTrace
+	ctx, t.task = trace.NewTask(ctx, taskType)
+	t.region = trace.StartRegion(ctx, regionType)
+	return context.WithValue(ctx, runtimeTraceKey{}, &t)
+}
+
+func (runtimeTraceHooks) OnPanic(ctx context.Context, task TaskInfo, p interface{}) {
+	trace.Log(ctx, "panic", fmt.Sprint(p))
+}
+
+func (runtimeTraceHooks) OnCancel(ctx context.Context, task TaskInfo, err error) {
+	trace.Log(ctx, "cancel", err.Error())
+}
+
+func (runtimeTraceHooks) OnEnd(ctx context.Context, task TaskInfo, err error) {
+	if err != nil {
+		trace.Log(ctx, "error", err.Error())
+	}
+	// The state is lost if another hook has not derived its context from ctx
+	if t, ok := ctx.Value(runtimeTraceKey{}).(*runtimeTrace); ok {
+		t.region.End()
+		t.task.End()
+	}
+}
@@ -0,0 +1,81 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"testing"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+func TestWithRuntimeTrace(t *testing.T) {
+	// Not parallel: the execution tracer is global
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skip("tracer unavailable:", err)
+	}
+	defer trace.Stop()
+
+	r := rendezvous.New(
+		rendezvous.WithName("fetch"),
+		rendezvous.WithTaskNames("cache"),
+		rendezvous.WithRuntimeTrace(),
+	)
+	err := r.WaitFirstError(context.Background(),
+		func(ctx context.Context) error {
+			for key, expected := range map[string]string{
+				"rendezvous": "fetch",
+				"task":       "0",
+				"task_name":  "cache",
+			} {
+				if v, _ := pprof.Label(ctx, key); v != expected {
+					t.Errorf("label %s: got %q, expected %q", key, v, expected)
+				}
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			if v, _ := pprof.Label(ctx, "task_name"); v != "" {
+				t.Errorf("label task_name: got %q", v)
+			}
+			return myErr
+		},
+	)
+	if err == nil {
+		t.Error("error expected")
+	}
+}
+
+func TestWithRuntimeTraceDetachedContext(t *testing.T) {
+	t.Parallel()
+
+	// The state of the trace hooks is lost by the next hook
+	err := rendezvous.New(
+		rendezvous.WithRuntimeTrace(),
+		rendezvous.WithHooks(panicHooks{detach: true}),
+	).WaitFirstError(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Participant is a resource updated by a [TwoPhaseCommit].
+type Participant interface {
+	// Prepare makes the update ready to be committed, or fails.
+	Prepare(ctx context.Context) error
+	// Commit applies the prepared update.
+	Commit(ctx context.Context) error
+	// Abort cancels the update. It may be called even if Prepare failed or was not called.
+	Abort(ctx context.Context) error
+}
+
+// TwoPhaseCommit coordinates an update of multiple participants with the two-phase
+// commit protocol.
+//
+// The zero value is an empty coordinator.
+type TwoPhaseCommit struct {
+	// Timeouts of each phase. With 0, there is no timeout.
+	PrepareTimeout time.Duration
+	CommitTimeout  time.Duration
+	AbortTimeout   time.Duration
+
+	participants []participant
+}
+
+type participant struct {
+	name string
+	Participant
+}
+
+// Add registers a participant.
+func (c *TwoPhaseCommit) Add(name string, p Participant) {
+	c.participants = append(c.participants, participant{name: name, Participant: p})
+}
+
+// ParticipantState is the final state of a participant of a [TwoPhaseCommit].
+type ParticipantState uint8
+
+const (
+	Aborted     ParticipantState = iota // Abort succeeded.
+	Committed                           // Commit succeeded.
+	InDoubt                             // Commit or Abort failed after Prepare succeeded: the state of the participant is unknown.
+	AbortFailed                         // Abort failed, but Prepare did not succeed: there is nothing to commit.
+)
+
+func (s ParticipantState) String() string {
+	switch s {
+	case Aborted:
+		return "aborted"
+	case Committed:
+		return "committed"
+	case InDoubt:
+		return "in doubt"
+	case AbortFailed:
+		return "abort failed"
+	default:
+		return "ParticipantState(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// TwoPhaseReport is the outcome of [TwoPhaseCommit.Run].
+type TwoPhaseReport struct {
+	Committed    bool                // The decision: true if all participants prepared successfully.
+	Participants []ParticipantReport // By order of [TwoPhaseCommit.Add].
+}
+
+// ParticipantReport is the outcome of a participant in a [TwoPhaseReport].
+type ParticipantReport struct {
+	Name       string
+	Prepared   bool // Prepare succeeded.
+	State      ParticipantState
+	PrepareErr error
+	CommitErr  error
+	AbortErr   error
+}
+
+// InDoubt returns the names of the participants in the [InDoubt] state.
+func (rep *TwoPhaseReport) InDoubt() []string {
+	var names []string
+	for i := range rep.Participants {
+		if rep.Participants[i].State == InDoubt {
+			names = append(names, rep.Participants[i].Name)
+		}
+	}
+	return names
+}
+
+// Run runs the Prepare of all participants with [WaitFirstError] semantics: the first
+// failure cancels the context of the others. Then, if all succeeded, the Commit of all
+// participants is run, else the Abort of all participants. Commit and Abort run concurrently,
+// with a context that is not a child of ctx (see CommitTimeout and AbortTimeout).
+//
+// The returned error, if not nil, wraps the errors of the participants, annotated with the
+// names of the participants and the phase. Even if it is not nil, the report is complete.
+func (c *TwoPhaseCommit) Run(ctx context.Context) (*TwoPhaseReport, error) {
+	rep := &TwoPhaseReport{
+		Participants: make([]ParticipantReport, len(c.participants)),
+	}
+	for i, p := range c.participants {
+		rep.Participants[i].Name = p.name
+	}
+
+	prepareCtx := ctx
+	if c.PrepareTimeout > 0 {
+		var cancel context.CancelFunc
+		prepareCtx, cancel = context.WithTimeout(ctx, c.PrepareTimeout)
+		defer cancel()
+	}
+	tasks := make([]TaskCtx, len(c.participants))
+	for i, p := range c.participants {
+		pr, p := &rep.Participants[i], p
+		tasks[i] = func(ctx context.Context) error {
+			if pr.PrepareErr = callTask(ctx, p.Prepare); pr.PrepareErr != nil {
+				return fmt.Errorf("%s: prepare: %w", p.name, pr.PrepareErr)
+			}
+			pr.Prepared = true
+			return nil
+		}
+	}
+	err := WaitFirstError(prepareCtx, tasks...)
+	rep.Committed = err == nil
+
+	phase, timeout := "abort", c.AbortTimeout
+	if rep.Committed {
+		phase, timeout = "commit", c.CommitTimeout
+	}
+	finishCtx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		finishCtx, cancel = context.WithTimeout(finishCtx, timeout)
+		defer cancel()
+	}
+	finish := make([]Task, len(c.participants))
+	errs := make([]error, 1+len(c.participants))
+	errs[0] = err
+	for i, p := range c.participants {
+		i, pr, p := i, &rep.Participants[i], p
+		finish[i] = func() error {
+			action, state, perr := p.Abort, Aborted, &pr.AbortErr
+			if rep.Committed {
+				action, state, perr = p.Commit, Committed, &pr.CommitErr
+			}
+			pr.State = state
+			if *perr = callTask(finishCtx, action); *perr != nil {
+				if pr.Prepared {
+					pr.State = InDoubt
+				} else {
+					pr.State = AbortFailed
+				}
+				errs[1+i] = fmt.Errorf("%s: %s: %w", p.name, phase, *perr)
+			}
+			return nil
+		}
+	}
+	WaitAll(finish...)
+
+	return rep, joinErrors(errs...)
+}
@@ -0,0 +1,152 @@
+/*
+   Copyright 2026 Olivier Mengué
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rendezvous_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dolmen-go/rendezvous"
+)
+
+type participant struct {
+	name       string
+	log        *eventLog
+	prepareErr error
+	commitErr  error
+	abortErr   error
+	slow       bool // Prepare waits for cancellation.
+}
+
+func (p *participant) Prepare(ctx context.Context) error {
+	if p.slow {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	p.log.add("prepare " + p.name)
+	return p.prepareErr
+}
+
+func (p *participant) Commit(ctx context.Context) error {
+	p.log.add("commit " + p.name)
+	return p.commitErr
+}
+
+func (p *participant) Abort(ctx context.Context) error {
+	p.log.add("abort " + p.name)
+	return p.abortErr
+}
+
+func TestTwoPhaseCommit(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	var c rendezvous.TwoPhaseCommit
+	c.Add("a", &participant{name: "a", log: &log})
+	c.Add("b", &participant{name: "b", log: &log})
+	rep, err := c.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rep.Committed || rep.InDoubt() != nil {
+		t.Errorf("got %+v", rep)
+	}
+	for _, p := range rep.Participants {
+		if !p.Prepared || p.State != rendezvous.Committed {
+			t.Errorf("got %+v", p)
+		}
+	}
+	sort.Strings(log.events)
+	checkEvents(t, log.events, []string{"commit a", "commit b", "prepare a", "prepare b"})
+}
+
+func TestTwoPhaseAbort(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	var c rendezvous.TwoPhaseCommit
+	c.Add("a", &participant{name: "a", log: &log, prepareErr: myErr})
+	c.Add("b", &participant{name: "b", log: &log, slow: true})
+	rep, err := c.Run(context.Background())
+	if !errors.Is(err, myErr) || rep.Committed {
+		t.Fatalf("got %v, %+v", err, rep)
+	}
+	t.Log(err)
+	if err.Error() != "a: prepare: my error\nb: prepare: context canceled" {
+		t.Errorf("got %q", err)
+	}
+	for _, p := range rep.Participants {
+		if p.Prepared || p.State != rendezvous.Aborted || p.PrepareErr == nil {
+			t.Errorf("got %+v", p)
+		}
+	}
+	sort.Strings(log.events)
+	checkEvents(t, log.events, []string{"abort a", "abort b", "prepare a"})
+}
+
+func TestTwoPhaseInDoubt(t *testing.T) {
+	t.Parallel()
+
+	var log eventLog
+	c := rendezvous.TwoPhaseCommit{PrepareTimeout: 10 * time.Millisecond}
+	c.Add("a", &participant{name: "a", log: &log, commitErr: myErr})
+	c.Add("b", &participant{name: "b", log: &log})
+	rep, err := c.Run(context.Background())
+	if !errors.Is(err, myErr) || err.Error() != "a: commit: my error" {
+		t.Fatalf("got %v", err)
+	}
+	if !rep.Committed {
+		t.Error("commit expected")
+	}
+	checkEvents(t, rep.InDoubt(), []string{"a"})
+	if rep.Participants[0].CommitErr != myErr || rep.Participants[1].State != rendezvous.Committed {
+		t.Errorf("got %+v", rep.Participants)
+	}
+	if s := rendezvous.InDoubt.String(); s != "in doubt" {
+		t.Errorf("got %q", s)
+	}
+
+	// Timeout of the prepare phase
+	c.Add("slow", &participant{name: "slow", log: &log, slow: true})
+	rep, err = c.Run(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) || rep.Committed {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestTwoPhaseAbortFailure(t *testing.T) {
+	t.Parallel()
+
+	errAbort := errors.New("abort failed")
+	var log eventLog
+	var c rendezvous.TwoPhaseCommit
+	// Prepared
+	c.Add("a", &participant{name: "a", log: &log, abortErr: errAbort})
+	// Not prepared
+	c.Add("b", &participant{name: "b", log: &log, prepareErr: myErr, abortErr: errAbort})
+	rep, err := c.Run(context.Background())
+	if !errors.Is(err, myErr) || !errors.Is(err, errAbort) || rep.Committed {
+		t.Fatalf("got %v", err)
+	}
+	checkEvents(t, rep.InDoubt(), []string{"a"})
+	if p := rep.Participants[1]; p.State != rendezvous.AbortFailed || p.AbortErr != errAbort {
+		t.Errorf("got %+v", p)
+	}
+}